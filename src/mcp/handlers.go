@@ -2,11 +2,15 @@ package mcp
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"kikokai/src/model"
+	"kikokai/src/render"
+	"kikokai/src/solver"
 	"log"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -18,6 +22,10 @@ type CubeEvent struct {
 	Layer     int                   `json:"layer,omitempty"`     // 1 ou -1
 	Direction int                   `json:"direction,omitempty"` // 1 pour sens horaire, -1 pour sens anti-horaire
 	State     [3][3][3]*model.Cubie `json:"state,omitempty"`
+	Moves     []model.Move          `json:"moves,omitempty"`  // moves to animate in order for a sequence
+	Source    string                `json:"source,omitempty"` // client that already applied the move locally
+	Scheme    *model.ColorScheme    `json:"scheme,omitempty"` // color scheme now in use
+	Goal      *model.GoalStatus     `json:"goal,omitempty"`   // progress toward the goal
 }
 
 // Interface for broadcasting events
@@ -48,7 +56,7 @@ func stateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallTo
 	}
 
 	// Return JSON-formatted state
-	return mcp.NewToolResultText(string(data)), nil
+	return withNet(mcp.NewToolResultText(string(data)), model.SharedCube), nil
 }
 
 func resetHandler(tx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -63,26 +71,30 @@ func resetHandler(tx context.Context, request mcp.CallToolRequest) (*mcp.CallToo
 
 	// Reset the cube using the new structure
 	model.ResetCube()
+	broadcastGoal()
 
 	// Send the response
-	return mcp.NewToolResultText(fmt.Sprintf("Cube state: %v", model.SharedCube.Cubies)), nil
+	return withNet(mcp.NewToolResultText("Cube reset"), model.SharedCube), nil
 }
 
 func scrambleHandler(tx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	log.Printf("Received MCP request: %s", CommandScramble)
 
-	// Broadcast the scramble event
+	// Scramble the cube using the new structure
+	model.SharedCube.Scramble(20) // Scramble with 20 random moves
+	model.RestartHistory()
+
+	// Broadcast the scrambled state, for browsers to show the cube the server turned
 	if Broadcaster != nil {
 		Broadcaster.BroadcastEvent(CubeEvent{
-			Type: "scramble",
+			Type:  "scramble",
+			State: model.SharedCube.Cubies,
 		})
 	}
-
-	// Scramble the cube using the new structure
-	model.SharedCube.Scramble(20) // Scramble with 20 random moves
+	broadcastGoal()
 
 	// Send the response
-	return mcp.NewToolResultText(fmt.Sprintf("Cube state: %v", model.SharedCube.Cubies)), nil
+	return withNet(mcp.NewToolResultText("Cube scrambled with 20 random moves"), model.SharedCube), nil
 }
 
 func rotateAxisHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -134,9 +146,577 @@ func rotateAxisHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.C
 
 	// Apply the rotation to the cube
 	model.SharedCube.RotateAxis(face, clockwise)
+	model.RecordMoves(model.Move{Axis: axis, Layer: int(layer), Direction: int(direction)})
+	broadcastGoal()
 
 	// Send the response
-	return mcp.NewToolResultText(fmt.Sprintf("Rotated cube: axis=%s, layer=%d, direction=%d", axis, int(layer), int(direction))), nil
+	return withNet(mcp.NewToolResultText(fmt.Sprintf("Rotated cube: axis=%s, layer=%d, direction=%d", axis, int(layer), int(direction))), model.SharedCube), nil
+}
+
+func moveHistoryHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: move_history")
+
+	data, err := json.MarshalIndent(model.MoveHistory(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal move history: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func hintHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: hint")
+
+	hint, err := solver.NextMove(model.SharedCube)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute hint: %v", err)
+	}
+
+	data, err := json.MarshalIndent(hint, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal hint: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func findPieceHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: find_piece")
+
+	var result any
+	if name, ok := request.Params.Arguments["piece"].(string); ok && name != "" {
+		location, err := model.SharedCube.LocatePiece(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find piece: %v", err)
+		}
+		result = location
+	} else {
+		locations, err := model.SharedCube.Pieces()
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate pieces: %v", err)
+		}
+		result = locations
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal pieces: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func diffHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: diff")
+
+	target := model.NewCube()
+	if name, ok := request.Params.Arguments["bookmark"].(string); ok && name != "" {
+		var err error
+		if target, err = model.BookmarkCube(name); err != nil {
+			return nil, fmt.Errorf("unable to read bookmark %q: %v", name, err)
+		}
+	}
+
+	diff, err := model.Diff(model.SharedCube, target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compare the cube: %v", err)
+	}
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal diff: %v", err)
+	}
+
+	summary := fmt.Sprintf("%d of %d pieces match, %d misplaced, %d misoriented", diff.Matching, diff.Total, diff.Misplaced, diff.Misoriented)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{mcp.NewTextContent(summary), mcp.NewTextContent(string(data))},
+	}, nil
+}
+
+func setGoalHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: set_goal")
+
+	pattern, _ := request.Params.Arguments["pattern"].(string)
+	state, _ := request.Params.Arguments["state"].(string)
+	bookmark, _ := request.Params.Arguments["bookmark"].(string)
+
+	switch {
+	case pattern != "" && (state != "" || bookmark != "") || state != "" && bookmark != "":
+		return nil, errors.New("give one of pattern, state or bookmark")
+	case state != "":
+		var cubies [3][3][3]*model.Cubie
+		if err := json.Unmarshal([]byte(state), &cubies); err != nil {
+			return nil, fmt.Errorf("unable to parse goal state: %v", err)
+		}
+		cube, err := model.CubeFromState(cubies)
+		if err != nil {
+			return nil, fmt.Errorf("invalid goal state: %v", err)
+		}
+		model.SetGoal(model.CustomGoal, cube)
+	case bookmark != "":
+		cube, err := model.BookmarkCube(bookmark)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read bookmark %q: %v", bookmark, err)
+		}
+		model.SetGoal(model.CustomGoal, cube)
+	default:
+		if pattern == "" {
+			pattern = model.SolvedGoal
+		}
+		if err := model.SetGoalPattern(pattern); err != nil {
+			return nil, fmt.Errorf("unable to set goal: %v", err)
+		}
+	}
+
+	// Report the progress toward the new goal, and reaching it if the cube already shows it
+	status := broadcastGoal()
+
+	goal, _ := model.Goal()
+	result := mcp.NewToolResultText(goalText(status))
+	result.Content = append(result.Content, mcp.NewTextContent("Goal net:\n"+goal.Render(model.TextLetters)))
+	return result, nil
+}
+
+// broadcastGoal sends the progress of the shared cube toward the goal, then a goal_reached
+// event when the cube has just reached it; call it after each change of the cube
+func broadcastGoal() model.GoalStatus {
+	status, reached := model.UpdateGoal(model.SharedCube)
+	if Broadcaster != nil {
+		Broadcaster.BroadcastEvent(CubeEvent{
+			Type: "goal",
+			Goal: &status,
+		})
+		if reached {
+			Broadcaster.BroadcastEvent(CubeEvent{
+				Type: "goal_reached",
+				Goal: &status,
+			})
+		}
+	}
+	return status
+}
+
+// goalText describes the progress toward the goal, e.g. "Goal checkerboard: 30 of 54 stickers match (55.6%)"
+func goalText(status model.GoalStatus) string {
+	if status.Reached {
+		return fmt.Sprintf("Goal %s reached", status.Name)
+	}
+	return fmt.Sprintf("Goal %s: %d of %d stickers match (%.1f%%)", status.Name, status.Matching, status.Total, status.Percent)
+}
+
+func checkStageHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: check_stage")
+
+	var statuses []model.StageStatus
+	if name, ok := request.Params.Arguments["stage"].(string); ok && name != "" {
+		stage, err := model.FindStage(name)
+		if err != nil {
+			return nil, err
+		}
+		status, err := stage.Check(model.SharedCube)
+		if err != nil {
+			return nil, fmt.Errorf("unable to check stage %q: %v", name, err)
+		}
+		statuses = append(statuses, status)
+	} else {
+		var err error
+		if statuses, err = model.SharedCube.CheckStages(); err != nil {
+			return nil, fmt.Errorf("unable to check stages: %v", err)
+		}
+	}
+
+	var summary strings.Builder
+	for _, status := range statuses {
+		state := "done"
+		if !status.Done {
+			state = fmt.Sprintf("%d of %d, missing %s", status.Matching, status.Total, strings.Join(status.Missing, " "))
+		}
+		fmt.Fprintf(&summary, "%s: %s\n", status.Name, state)
+	}
+
+	data, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal stages: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{mcp.NewTextContent(summary.String()), mcp.NewTextContent(string(data))},
+	}, nil
+}
+
+func lastLayerCaseHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: last_layer_case")
+
+	found, err := model.SharedCube.RecognizeLastLayer()
+	if err != nil {
+		return nil, fmt.Errorf("unable to recognize the last layer case: %v", err)
+	}
+
+	data, err := json.MarshalIndent(found, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal last layer case: %v", err)
+	}
+
+	var summary string
+	switch {
+	case found.Step == "solved":
+		summary = "The cube is solved"
+	case found.Case == "skip":
+		summary = fmt.Sprintf("PLL skip, finish with %s", found.Solution)
+	case found.Step == "pll":
+		summary = fmt.Sprintf("PLL %s, solved by %s", found.Case, found.Solution)
+	default:
+		summary = fmt.Sprintf("%s, solved by %s", found.Case, found.Solution)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{mcp.NewTextContent(summary), mcp.NewTextContent(string(data))},
+	}, nil
+}
+
+func renderHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: render")
+
+	args := request.Params.Arguments
+	opts := render.DefaultOptions()
+	if _, ok := args["size"]; ok {
+		size, err := getFloatParam(args, "size")
+		if err != nil {
+			return nil, err
+		}
+		opts.Size = int(size)
+	}
+	if name, ok := args["scheme"].(string); ok && name != "" {
+		scheme, err := model.LookupColorScheme(name)
+		if err != nil {
+			return nil, err
+		}
+		opts.Scheme = scheme
+	}
+
+	// Without a view, show both: the isometric view reads like the real cube,
+	// the net shows all 54 stickers
+	views := []render.View{render.ViewIsometric, render.ViewNet}
+	if name, ok := args["view"].(string); ok && name != "" {
+		view, err := render.ParseView(name)
+		if err != nil {
+			return nil, err
+		}
+		views = []render.View{view}
+	}
+
+	result := &mcp.CallToolResult{}
+	for _, view := range views {
+		opts.View = view
+		picture, err := render.PNG(model.SharedCube, opts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render the cube: %v", err)
+		}
+		result.Content = append(result.Content,
+			mcp.NewTextContent(describeView(view)),
+			mcp.NewImageContent(base64.StdEncoding.EncodeToString(picture), "image/png"))
+	}
+	return result, nil
+}
+
+// describeView tells how to read a picture of the cube
+func describeView(view render.View) string {
+	if view == render.ViewIsometric {
+		return "Isometric view of the Up (top), Front (left) and Right (right) faces"
+	}
+	return "Unfolded net: Up on top; Left, Front, Right and Back in the middle row; Down below Front"
+}
+
+func setColorSchemeHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: set_color_scheme")
+
+	args := request.Params.Arguments
+	name, _ := args["name"].(string)
+	var err error
+	if colors, ok := args["colors"]; ok {
+		// Round-trip through JSON to decode the generic array into scheme colors
+		var scheme model.ColorScheme
+		data, _ := json.Marshal(colors)
+		var custom []model.SchemeColor
+		if err := json.Unmarshal(data, &custom); err != nil || len(custom) != len(scheme.Colors) {
+			return nil, errors.New("colors must be a list of 6 {name, hex, letter} colors")
+		}
+		scheme.Name = name
+		if scheme.Name == "" {
+			scheme.Name = "custom"
+		}
+		copy(scheme.Colors[:], custom)
+		scheme.Letters, _ = args["letters"].(bool)
+		err = model.SetColorScheme(scheme)
+	} else {
+		if name == "" {
+			return nil, errors.New("a scheme name or a list of colors is required")
+		}
+		err = model.UseColorScheme(name)
+		if letters, ok := args["letters"].(bool); ok && err == nil {
+			scheme := model.CurrentColorScheme()
+			scheme.Letters = letters
+			err = model.SetColorScheme(scheme)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to set color scheme: %v", err)
+	}
+
+	scheme := model.CurrentColorScheme()
+	if Broadcaster != nil {
+		Broadcaster.BroadcastEvent(CubeEvent{
+			Type:   "scheme",
+			Scheme: &scheme,
+		})
+	}
+
+	data, err := json.MarshalIndent(scheme, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal color scheme: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func saveBookmarkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: save_bookmark")
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok {
+		return nil, errors.New("name must be a string")
+	}
+
+	bookmark, err := model.SaveBookmark(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Saved bookmark %q", bookmark.Name)), nil
+}
+
+func listBookmarksHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: list_bookmarks")
+
+	data, err := json.MarshalIndent(model.Bookmarks(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal bookmarks: %v", err)
+	}
+
+	return mcp.NewToolResultText(string(data)), nil
+}
+
+func restoreBookmarkHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: restore_bookmark")
+
+	name, ok := request.Params.Arguments["name"].(string)
+	if !ok {
+		return nil, errors.New("name must be a string")
+	}
+
+	if err := model.RestoreBookmark(name); err != nil {
+		return nil, fmt.Errorf("unable to restore bookmark %q: %v", name, err)
+	}
+
+	// Broadcast the full state so that browsers redraw the restored cube
+	if Broadcaster != nil {
+		Broadcaster.BroadcastEvent(CubeEvent{
+			Type:  "state",
+			State: model.SharedCube.Cubies,
+		})
+	}
+	broadcastGoal()
+
+	return withNet(mcp.NewToolResultText(fmt.Sprintf("Restored bookmark %q", name)), model.SharedCube), nil
+}
+
+// forkResult is the state of a fork returned by the fork tools
+type forkResult struct {
+	ID    string                `json:"id"`
+	Moves []model.Move          `json:"moves"`
+	State [3][3][3]*model.Cubie `json:"state"`
+}
+
+func newForkResult(fork model.Fork) (*mcp.CallToolResult, error) {
+	cube := fork.Cube()
+	data, err := json.MarshalIndent(forkResult{
+		ID:    fork.ID,
+		Moves: fork.Moves,
+		State: cube.Cubies,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal fork state: %v", err)
+	}
+	return withNet(mcp.NewToolResultText(string(data)), cube), nil
+}
+
+func forkCreateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: fork_create")
+
+	return newForkResult(model.CreateFork())
+}
+
+func forkApplyHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: fork_apply")
+
+	id, ok := request.Params.Arguments["id"].(string)
+	if !ok {
+		return nil, errors.New("id must be a string")
+	}
+
+	moves, err := getMovesArgs(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	fork, err := model.ApplyToFork(id, moves)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply moves to fork %q: %v", id, err)
+	}
+
+	return newForkResult(fork)
+}
+
+func forkStateHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: fork_state")
+
+	id, ok := request.Params.Arguments["id"].(string)
+	if !ok {
+		return nil, errors.New("id must be a string")
+	}
+
+	fork, err := model.GetFork(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get fork %q: %v", id, err)
+	}
+
+	return newForkResult(fork)
+}
+
+func forkCommitHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: fork_commit")
+
+	id, ok := request.Params.Arguments["id"].(string)
+	if !ok {
+		return nil, errors.New("id must be a string")
+	}
+
+	moves, err := model.CommitFork(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to commit fork %q: %v", id, err)
+	}
+
+	// Broadcast the committed moves as a single sequence
+	if Broadcaster != nil && len(moves) > 0 {
+		log.Printf("Broadcasting MCP fork commit: %d moves", len(moves))
+		Broadcaster.BroadcastEvent(CubeEvent{
+			Type:  "sequence",
+			Moves: moves,
+		})
+	}
+	broadcastGoal()
+
+	return withNet(mcp.NewToolResultText(fmt.Sprintf("Committed %d moves from fork %q to the cube", len(moves), id)), model.SharedCube), nil
+}
+
+func forkDiscardHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: fork_discard")
+
+	id, ok := request.Params.Arguments["id"].(string)
+	if !ok {
+		return nil, errors.New("id must be a string")
+	}
+
+	if err := model.DiscardFork(id); err != nil {
+		return nil, fmt.Errorf("unable to discard fork %q: %v", id, err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Discarded fork %q", id)), nil
+}
+
+func applyMovesHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	log.Printf("Received MCP request: apply_moves")
+
+	moves, err := getMovesArgs(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	// All moves are validated before the first one is applied
+	if err := model.SharedCube.ApplyMoves(moves); err != nil {
+		return nil, err
+	}
+	model.RecordMoves(moves...)
+
+	// Broadcast the whole batch as a single sequence
+	if Broadcaster != nil && len(moves) > 0 {
+		log.Printf("Broadcasting MCP sequence: %d moves", len(moves))
+		Broadcaster.BroadcastEvent(CubeEvent{
+			Type:  "sequence",
+			Moves: moves,
+		})
+	}
+	status := broadcastGoal()
+
+	data, err := json.MarshalIndent(struct {
+		Algorithm string                `json:"algorithm"`
+		Moves     []model.Move          `json:"moves"`
+		State     [3][3][3]*model.Cubie `json:"state"`
+	}{
+		Algorithm: model.FormatAlgorithm(moves),
+		Moves:     moves,
+		State:     model.SharedCube.Cubies,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal cube state: %v", err)
+	}
+
+	result := withNet(mcp.NewToolResultText(string(data)), model.SharedCube)
+	result.Content = append(result.Content, mcp.NewTextContent(goalText(status)))
+	return result, nil
+}
+
+// withNet adds the cube written as a text net to a tool result, for clients to read at a glance
+func withNet(result *mcp.CallToolResult, c *model.Cube) *mcp.CallToolResult {
+	net := "Net (Up on top; Left, Front, Right, Back in the middle; Down below):\n" + c.Render(model.TextLetters)
+	result.Content = append(result.Content, mcp.NewTextContent(net))
+	return result
+}
+
+// getMovesArgs reads the moves of a request, given either as an "algorithm"
+// in standard notation or as a "moves" list of axis rotations
+func getMovesArgs(args map[string]interface{}) ([]model.Move, error) {
+	algorithm, hasAlgorithm := args["algorithm"].(string)
+	_, hasMoves := args["moves"]
+
+	switch {
+	case hasAlgorithm && algorithm != "" && hasMoves:
+		return nil, errors.New("provide either an algorithm or a list of moves, not both")
+	case hasAlgorithm && algorithm != "":
+		return model.ParseAlgorithm(algorithm)
+	case hasMoves:
+		return getMovesParam(args, "moves")
+	default:
+		return nil, errors.New("an algorithm or a list of moves is required")
+	}
+}
+
+// getMovesParam extracts a list of moves from an array parameter
+func getMovesParam(args map[string]interface{}, name string) ([]model.Move, error) {
+	val, ok := args[name]
+	if !ok {
+		return nil, fmt.Errorf("%s parameter is required", name)
+	}
+
+	// Round-trip through JSON to decode the generic array into moves
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %v", name, err)
+	}
+	var moves []model.Move
+	if err := json.Unmarshal(data, &moves); err != nil {
+		return nil, fmt.Errorf("%s must be a list of {axis, layer, direction} moves", name)
+	}
+	return moves, nil
 }
 
 // Fonction utilitaire pour extraire un paramètre numérique
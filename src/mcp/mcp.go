@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"kikokai/src/model"
 	"log"
 	"net/http"
 	"os"
@@ -21,6 +22,46 @@ possible action are
  - 'rotate' to rotate a cube layer based on axis, layer and direction this action requires a body to indicate the axis (x, y, z), layer (1 or -1) and direction (1 for clockwise, -1 for counter-clockwise)
 `
 
+// moveSchema describes a single layer turn in tool parameters
+var moveSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"axis": map[string]any{
+			"type":        "string",
+			"description": "Rotation axis (x, y, z)",
+		},
+		"layer": map[string]any{
+			"type":        "number",
+			"description": "Layer to rotate (1 or -1)",
+		},
+		"direction": map[string]any{
+			"type":        "number",
+			"description": "Rotation direction (1 for clockwise, -1 for counter-clockwise)",
+		},
+	},
+	"required": []string{"axis", "layer", "direction"},
+}
+
+// schemeColorSchema describes one color of a custom color scheme in tool parameters
+var schemeColorSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"name": map[string]any{
+			"type":        "string",
+			"description": "Name of the color, e.g. \"white\"",
+		},
+		"hex": map[string]any{
+			"type":        "string",
+			"description": "Color value as #RRGGBB",
+		},
+		"letter": map[string]any{
+			"type":        "string",
+			"description": "Single letter drawn on the stickers, e.g. \"W\"",
+		},
+	},
+	"required": []string{"name", "hex", "letter"},
+}
+
 func StartMCPServer() {
 	// Create MCP server
 	mcpServer := server.NewMCPServer(
@@ -125,6 +166,187 @@ func StartMCPServer() {
 	// Add scramble tool handler
 	mcpServer.AddTool(scramble, scrambleHandler)
 
+	// Add apply-moves tool
+	applyMoves := mcp.NewTool("apply_moves",
+		mcp.WithDescription("apply a whole algorithm to the cube at once, given either in standard notation or as a list of axis rotations"),
+		mcp.WithString("algorithm",
+			mcp.Description("Algorithm in standard face notation, e.g. \"R U R' U'\""),
+		),
+		mcp.WithArray("moves",
+			mcp.Description("Moves to apply in order, used when no algorithm is given"),
+			mcp.Items(moveSchema),
+		),
+	)
+	mcpServer.AddTool(applyMoves, applyMovesHandler)
+
+	// Add move history tool
+	moveHistory := mcp.NewTool("move_history",
+		mcp.WithDescription("get the moves applied to the cube since it was last reset, scrambled or replaced, with the state they started from"),
+	)
+	mcpServer.AddTool(moveHistory, moveHistoryHandler)
+
+	// Add hint tool
+	hint := mcp.NewTool("hint",
+		mcp.WithDescription("get the next move toward the solved cube, with the number of face turns left in the solution found"),
+	)
+	mcpServer.AddTool(hint, hintHandler)
+
+	// Add find_piece tool
+	findPiece := mcp.NewTool("find_piece",
+		mcp.WithDescription("find where a corner or edge is and how it is oriented: its home slot, current slot (e.g. URF), and twist (corners, 0 to 2 clockwise) or flip (edges, 0 or 1)"),
+		mcp.WithString("piece",
+			mcp.Description("Colors of the piece in any order, e.g. \"white-green-orange\"; every piece is listed without it"),
+		),
+	)
+	mcpServer.AddTool(findPiece, findPieceHandler)
+
+	// Add diff tool
+	diff := mcp.NewTool("diff",
+		mcp.WithDescription("compare the cube with the solved cube or a bookmark: the misplaced and misoriented pieces, and how many already match"),
+		mcp.WithString("bookmark",
+			mcp.Description("Name of a bookmark to compare with instead of the solved cube"),
+		),
+	)
+	mcpServer.AddTool(diff, diffHandler)
+
+	// Add set_goal tool
+	setGoal := mcp.NewTool("set_goal",
+		mcp.WithDescription("set the cube to reach instead of the solved cube, as a pattern, a full state or a bookmark; the progress toward it is reported after apply_moves and broadcast to browsers"),
+		mcp.WithString("pattern",
+			mcp.Description("Pattern to reach: "+strings.Join(model.PatternNames(), ", ")),
+		),
+		mcp.WithString("state",
+			mcp.Description("State to reach, as the JSON of the state array returned by the state tool"),
+		),
+		mcp.WithString("bookmark",
+			mcp.Description("Name of a bookmark whose state is to be reached"),
+		),
+	)
+	mcpServer.AddTool(setGoal, setGoalHandler)
+
+	// Add check_stage tool
+	stageNames := make([]string, len(model.Stages))
+	for i, stage := range model.Stages {
+		stageNames[i] = stage.Name
+	}
+	checkStage := mcp.NewTool("check_stage",
+		mcp.WithDescription("check how far the cube is in the stages of a solve with the cross on Down: which stickers (e.g. F8) or edge slots are still wrong"),
+		mcp.WithString("stage",
+			mcp.Description("Stage to check: "+strings.Join(stageNames, ", ")+"; every stage is checked without it"),
+		),
+	)
+	mcpServer.AddTool(checkStage, checkStageHandler)
+
+	// Add last_layer_case tool
+	lastLayerCase := mcp.NewTool("last_layer_case",
+		mcp.WithDescription("once the first two layers are solved, recognize the OLL case (57 cases) or, once the last layer is oriented, the PLL case (21 cases), with the U turn to make first and an algorithm in face turns"),
+	)
+	mcpServer.AddTool(lastLayerCase, lastLayerCaseHandler)
+
+	// Add render tool
+	renderTool := mcp.NewTool("render",
+		mcp.WithDescription("get a PNG picture of the cube, as an isometric view and an unfolded net"),
+		mcp.WithString("view",
+			mcp.Description("Only render this view: net or isometric"),
+		),
+		mcp.WithNumber("size",
+			mcp.Description("Width of each picture in pixels, 480 by default"),
+		),
+		mcp.WithString("scheme",
+			mcp.Description("Predefined color scheme to draw with instead of the current one"),
+		),
+	)
+	mcpServer.AddTool(renderTool, renderHandler)
+
+	// Add color scheme tool
+	setColorScheme := mcp.NewTool("set_color_scheme",
+		mcp.WithDescription("change how the six colors are named and drawn, choosing a predefined scheme (western, japanese, high-contrast, color-blind) or giving custom colors"),
+		mcp.WithString("name",
+			mcp.Description("Name of a predefined scheme, or of the custom scheme when colors are given"),
+		),
+		mcp.WithArray("colors",
+			mcp.Description("Custom colors of the front, right, back, left, up and down faces of the solved cube, in that order"),
+			mcp.Items(schemeColorSchema),
+		),
+		mcp.WithBoolean("letters",
+			mcp.Description("Draw each sticker's letter over its color"),
+		),
+	)
+	mcpServer.AddTool(setColorScheme, setColorSchemeHandler)
+
+	// Add bookmark tools
+	saveBookmark := mcp.NewTool("save_bookmark",
+		mcp.WithDescription("save the current state of the cube under a name"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the bookmark"),
+		),
+	)
+	mcpServer.AddTool(saveBookmark, saveBookmarkHandler)
+
+	listBookmarks := mcp.NewTool("list_bookmarks",
+		mcp.WithDescription("list the saved cube states"),
+	)
+	mcpServer.AddTool(listBookmarks, listBookmarksHandler)
+
+	restoreBookmark := mcp.NewTool("restore_bookmark",
+		mcp.WithDescription("restore the cube to a previously saved state"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Name of the bookmark"),
+		),
+	)
+	mcpServer.AddTool(restoreBookmark, restoreBookmarkHandler)
+
+	// Add fork tools, to try moves on a scratch copy of the cube
+	forkCreate := mcp.NewTool("fork_create",
+		mcp.WithDescription("clone the current cube into a scratch fork, moves applied to the fork are not shown to viewers"),
+	)
+	mcpServer.AddTool(forkCreate, forkCreateHandler)
+
+	forkApply := mcp.NewTool("fork_apply",
+		mcp.WithDescription("apply a sequence of moves to a fork and return its state"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Identifier of the fork"),
+		),
+		mcp.WithString("algorithm",
+			mcp.Description("Algorithm in standard face notation, e.g. \"R U R' U'\""),
+		),
+		mcp.WithArray("moves",
+			mcp.Description("Moves to apply in order, used when no algorithm is given"),
+			mcp.Items(moveSchema),
+		),
+	)
+	mcpServer.AddTool(forkApply, forkApplyHandler)
+
+	forkState := mcp.NewTool("fork_state",
+		mcp.WithDescription("get the state and the moves of a fork"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Identifier of the fork"),
+		),
+	)
+	mcpServer.AddTool(forkState, forkStateHandler)
+
+	forkCommit := mcp.NewTool("fork_commit",
+		mcp.WithDescription("apply the moves of a fork to the live cube as one animated sequence and remove the fork"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Identifier of the fork"),
+		),
+	)
+	mcpServer.AddTool(forkCommit, forkCommitHandler)
+
+	forkDiscard := mcp.NewTool("fork_discard",
+		mcp.WithDescription("remove a fork without touching the live cube"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Identifier of the fork"),
+		),
+	)
+	mcpServer.AddTool(forkDiscard, forkDiscardHandler)
+
 	// Configure SSE server: SSE at "/", JSON-RPC at "/message"
 	// The SSEServer itself implements http.Handler
 	sseMCPHandler := server.NewSSEServer(mcpServer,
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bookmark errors
+var (
+	ErrBookmarkName     = errors.New("bookmark name must not be empty")
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+)
+
+// Bookmark is a named snapshot of the shared cube
+type Bookmark struct {
+	Name    string    `json:"name"`
+	SavedAt time.Time `json:"savedAt"`
+	cube    *Cube
+}
+
+var (
+	bookmarks     = make(map[string]*Bookmark)
+	bookmarksLock sync.Mutex
+)
+
+// SaveBookmark stores a copy of the shared cube under the given name,
+// replacing any bookmark previously saved with the same name
+func SaveBookmark(name string) (Bookmark, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Bookmark{}, ErrBookmarkName
+	}
+
+	bookmark := &Bookmark{
+		Name:    name,
+		SavedAt: time.Now(),
+		cube:    SharedCube.Clone(),
+	}
+
+	bookmarksLock.Lock()
+	defer bookmarksLock.Unlock()
+	bookmarks[name] = bookmark
+	return *bookmark, nil
+}
+
+// Bookmarks returns the saved bookmarks sorted by name
+func Bookmarks() []Bookmark {
+	bookmarksLock.Lock()
+	defer bookmarksLock.Unlock()
+
+	list := make([]Bookmark, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		list = append(list, *bookmark)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// BookmarkCube returns a copy of the cube saved under the given name
+func BookmarkCube(name string) (*Cube, error) {
+	bookmarksLock.Lock()
+	defer bookmarksLock.Unlock()
+
+	bookmark, ok := bookmarks[strings.TrimSpace(name)]
+	if !ok {
+		return nil, ErrBookmarkNotFound
+	}
+	return bookmark.cube.Clone(), nil
+}
+
+// RestoreBookmark replaces the shared cube with a copy of the named bookmark
+func RestoreBookmark(name string) error {
+	cube, err := BookmarkCube(name)
+	if err != nil {
+		return err
+	}
+	SharedCube = cube
+	RestartHistory()
+	return nil
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBookmarks_SaveAndRestore(t *testing.T) {
+	ResetCube()
+	SharedCube.RotateAxis(FrontAxis, Clockwise)
+	want, _ := SharedCube.ToReadableJSON()
+
+	if _, err := SaveBookmark("after-front"); err != nil {
+		t.Fatalf("SaveBookmark failed: %v", err)
+	}
+
+	// Changing the shared cube must not change the bookmark
+	SharedCube.RotateAxis(UpAxis, Clockwise)
+
+	if err := RestoreBookmark("after-front"); err != nil {
+		t.Fatalf("RestoreBookmark failed: %v", err)
+	}
+	got, _ := SharedCube.ToReadableJSON()
+	if got != want {
+		t.Errorf("Restored cube = %s, want %s", got, want)
+	}
+
+	// Changing the restored cube must not change the bookmark either
+	SharedCube.RotateAxis(RightAxis, Clockwise)
+	if err := RestoreBookmark("after-front"); err != nil {
+		t.Fatalf("RestoreBookmark failed: %v", err)
+	}
+	got, _ = SharedCube.ToReadableJSON()
+	if got != want {
+		t.Errorf("Restored cube after second restore = %s, want %s", got, want)
+	}
+}
+
+func TestBookmarks_Errors(t *testing.T) {
+	if _, err := SaveBookmark("  "); !errors.Is(err, ErrBookmarkName) {
+		t.Errorf("SaveBookmark with empty name error = %v, want %v", err, ErrBookmarkName)
+	}
+	if err := RestoreBookmark("does-not-exist"); !errors.Is(err, ErrBookmarkNotFound) {
+		t.Errorf("RestoreBookmark with unknown name error = %v, want %v", err, ErrBookmarkNotFound)
+	}
+}
+
+func TestBookmarks_ListSorted(t *testing.T) {
+	ResetCube()
+	for _, name := range []string{"zeta", "alpha", "mid"} {
+		if _, err := SaveBookmark(name); err != nil {
+			t.Fatalf("SaveBookmark(%q) failed: %v", name, err)
+		}
+	}
+
+	var names []string
+	for _, bookmark := range Bookmarks() {
+		switch bookmark.Name {
+		case "zeta", "alpha", "mid":
+			names = append(names, bookmark.Name)
+		}
+	}
+	want := []string{"alpha", "mid", "zeta"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Bookmarks() names = %v, want %v", names, want)
+	}
+}
@@ -1,11 +1,19 @@
 package model
 
-// Color represents a color on the Rubik's cube
-type Color int        // Color constants
-type StickerIndex int // Sticker constants
+import (
+	"fmt"
+	"strings"
+)
+
+// Color represents a color on the Rubik's cube. It stands for the face the sticker covers
+// in the solved cube (White for Front, Blue for Up...); the current ColorScheme decides
+// how it is named and drawn.
+type Color int
+
+// StickerIndex identifies one of the 54 stickers by its place on the cube, see NewStickerIndex
+type StickerIndex int
 
 const (
-	// Common Colors
 	White Color = iota
 	Orange
 	Yellow
@@ -14,144 +22,173 @@ const (
 	Green
 )
 
-// ColorNames maps Color constants to their string representations
+// StickerCount is the number of stickers on the cube
+const StickerCount = 54
+
+// stickerFaceLetters names the faces in the order of FaceIndex, to name the stickers
+const stickerFaceLetters = "FRBLUD"
+
+// NewStickerIndex returns the index of the sticker at a row and column of a face, read as in Cube.Face.
+// Stickers are numbered face by face in the order of FaceIndex, then row by row: face*9 + row*3 + col.
+func NewStickerIndex(face FaceIndex, row, col int) StickerIndex {
+	return StickerIndex(int(face)*9 + row*3 + col)
+}
+
+// Face returns the face the sticker is on
+func (s StickerIndex) Face() FaceIndex {
+	return FaceIndex(s / 9)
+}
+
+// Row returns the row of the sticker on its face, 0 at the top
+func (s StickerIndex) Row() int {
+	return int(s) % 9 / 3
+}
+
+// Col returns the column of the sticker on its face, 0 on the left
+func (s StickerIndex) Col() int {
+	return int(s) % 3
+}
+
+// Position returns the indices in Cube.Cubies of the cubie carrying the sticker
+func (s StickerIndex) Position() CubeCoordinate {
+	return facePosition(s.Face(), s.Row(), s.Col())
+}
+
+// Valid reports whether the index designates one of the 54 stickers
+func (s StickerIndex) Valid() bool {
+	return s >= 0 && s < StickerCount
+}
+
+// String names the sticker by the letter of its face and its number in reading order,
+// from 1 to 9, e.g. "U5" for the center of the Up face
+func (s StickerIndex) String() string {
+	if !s.Valid() {
+		return fmt.Sprintf("StickerIndex(%d)", int(s))
+	}
+	return fmt.Sprintf("%c%d", stickerFaceLetters[s.Face()], int(s)%9+1)
+}
+
+// ParseSticker returns the sticker with the given name, e.g. "U5" or "f1"
+func ParseSticker(name string) (StickerIndex, error) {
+	if len(name) != 2 {
+		return 0, fmt.Errorf("invalid sticker %q, expected a face letter and a number from 1 to 9, e.g. U5", name)
+	}
+	face := strings.IndexByte(stickerFaceLetters, strings.ToUpper(name)[0])
+	number := int(name[1] - '0')
+	if face < 0 || number < 1 || number > 9 {
+		return 0, fmt.Errorf("invalid sticker %q, expected a face letter and a number from 1 to 9, e.g. U5", name)
+	}
+	return StickerIndex(face*9 + number - 1), nil
+}
+
+// Sticker returns the color of a sticker
+func (c *Cube) Sticker(s StickerIndex) (Color, error) {
+	if !s.Valid() {
+		return 0, fmt.Errorf("invalid sticker index %d", int(s))
+	}
+	color, ok := c.stickerColor(s.Position(), s.Face())
+	if !ok {
+		return 0, fmt.Errorf("missing sticker %s", s)
+	}
+	return color, nil
+}
+
+// FaceColorName names the color of each face in the solved cube.
+//
+// Deprecated: use the canonical names of ParseColor and PieceName, or
+// CurrentColorScheme().Color(color).Name for the name shown to the user.
 var FaceColorName = map[FaceIndex]string{
-	Front: "white",
-	Right: "orange",
-	Back:  "yellow",
-	Left:  "red",
-	Up:    "blue",
-	Down:  "green",
+	Front: colorToName(White),
+	Right: colorToName(Orange),
+	Back:  colorToName(Yellow),
+	Left:  colorToName(Red),
+	Up:    colorToName(Blue),
+	Down:  colorToName(Green),
 }
 
+// Stickers named by their face and the indices of their cubie, in an order that differs from face to face.
+//
+// Deprecated: use NewStickerIndex or ParseSticker, which number the stickers as on the net.
 const (
-	// Sticker Colors
-	// Front Face (x=1) => 2 Y Z
-	Front_2_0_0 StickerIndex = iota
-	Front_2_0_1
-	Front_2_0_2
-	Front_2_1_0
-	Front_2_1_1
-	Front_2_1_2
-	Front_2_2_0
-	Front_2_2_1
-	Front_2_2_2
-	// Back Face (x=-1) => 0 Z Y
-	Back_0_0_0
-	Back_0_0_1
-	Back_0_0_2
-	Back_0_1_0
-	Back_0_1_1
-	Back_0_1_2
-	Back_0_2_0
-	Back_0_2_1
-	Back_0_2_2
-	// Up Face (z=1) => Y X 2
-	Up_0_0_2
-	Up_1_0_2
-	Up_2_0_2
-	Up_0_1_2
-	Up_1_1_2
-	Up_2_1_2
-	Up_0_2_2
-	Up_1_2_2
-	Up_2_2_2
-	// Down Face (z=-1) => Y X 0
-	Down_0_0_0
-	Down_1_0_0
-	Down_2_0_0
-	Down_0_1_0
-	Down_1_1_0
-	Down_2_1_0
-	Down_0_2_0
-	Down_1_2_0
-	Down_2_2_0
-	// Left Face (y=-1) => Z 0 X
-	Left_0_0_0
-	Left_0_0_1
-	Left_0_0_2
-	Left_1_0_0
-	Left_1_0_1
-	Left_1_0_2
-	Left_2_0_0
-	Left_2_0_1
-	Left_2_0_2
-	// Right Face (y=1) => Z 2 X
-	Right_0_2_0
-	Right_0_2_1
-	Right_0_2_2
-	Right_1_2_0
-	Right_1_2_1
-	Right_1_2_2
-	Right_2_2_0
-	Right_2_2_1
-	Right_2_2_2
+	// Front face, by cubie y and z
+	Front_2_0_0 StickerIndex = 6
+	Front_2_0_1 StickerIndex = 7
+	Front_2_0_2 StickerIndex = 8
+	Front_2_1_0 StickerIndex = 3
+	Front_2_1_1 StickerIndex = 4
+	Front_2_1_2 StickerIndex = 5
+	Front_2_2_0 StickerIndex = 0
+	Front_2_2_1 StickerIndex = 1
+	Front_2_2_2 StickerIndex = 2
+	// Back face, by cubie z and y
+	Back_0_0_0 StickerIndex = 26
+	Back_0_0_1 StickerIndex = 23
+	Back_0_0_2 StickerIndex = 20
+	Back_0_1_0 StickerIndex = 25
+	Back_0_1_1 StickerIndex = 22
+	Back_0_1_2 StickerIndex = 19
+	Back_0_2_0 StickerIndex = 24
+	Back_0_2_1 StickerIndex = 21
+	Back_0_2_2 StickerIndex = 18
+	// Up face, by cubie x and z
+	Up_0_0_2 StickerIndex = 36
+	Up_1_0_2 StickerIndex = 39
+	Up_2_0_2 StickerIndex = 42
+	Up_0_1_2 StickerIndex = 37
+	Up_1_1_2 StickerIndex = 40
+	Up_2_1_2 StickerIndex = 43
+	Up_0_2_2 StickerIndex = 38
+	Up_1_2_2 StickerIndex = 41
+	Up_2_2_2 StickerIndex = 44
+	// Down face, by cubie z and x
+	Down_0_0_0 StickerIndex = 51
+	Down_1_0_0 StickerIndex = 52
+	Down_2_0_0 StickerIndex = 53
+	Down_0_1_0 StickerIndex = 48
+	Down_1_1_0 StickerIndex = 49
+	Down_2_1_0 StickerIndex = 50
+	Down_0_2_0 StickerIndex = 45
+	Down_1_2_0 StickerIndex = 46
+	Down_2_2_0 StickerIndex = 47
+	// Left face, by cubie y and x
+	Left_0_0_0 StickerIndex = 33
+	Left_0_0_1 StickerIndex = 34
+	Left_0_0_2 StickerIndex = 35
+	Left_1_0_0 StickerIndex = 30
+	Left_1_0_1 StickerIndex = 31
+	Left_1_0_2 StickerIndex = 32
+	Left_2_0_0 StickerIndex = 27
+	Left_2_0_1 StickerIndex = 28
+	Left_2_0_2 StickerIndex = 29
+	// Right face, by cubie y and x
+	Right_0_2_0 StickerIndex = 17
+	Right_0_2_1 StickerIndex = 16
+	Right_0_2_2 StickerIndex = 15
+	Right_1_2_0 StickerIndex = 14
+	Right_1_2_1 StickerIndex = 13
+	Right_1_2_2 StickerIndex = 12
+	Right_2_2_0 StickerIndex = 11
+	Right_2_2_1 StickerIndex = 10
+	Right_2_2_2 StickerIndex = 9
 )
 
-var StickerColorName = map[StickerIndex]string{
-	// Front Face (White) YZ
-	Front_2_0_0: "white_green_red",    // bottom-left (row 0, col 0)
-	Front_2_0_1: "white_green",        // bottom-center (row 0, col 1)
-	Front_2_0_2: "white_green_orange", // bottom-right (row 0, col 2)
-	Front_2_1_0: "white_red",          // middle-left (row 1, col 0)
-	Front_2_1_1: "white",              // center (row 1, col 1)
-	Front_2_1_2: "white_orange",       // middle-right (row 1, col 2)
-	Front_2_2_0: "white_blue_red",     // top-left (row 2, col 0)
-	Front_2_2_1: "white_blue",         // top-center (row 2, col 1)
-	Front_2_2_2: "white_blue_orange",  // top-right (row 2, col 2)
-
-	// Back Face (Yellow) ZY
-	Back_0_0_0: "yellow_green_red",    // bottom-left (row 0, col 0)
-	Back_0_0_1: "yellow_red",          // bottom-center (row 0, col 1)
-	Back_0_0_2: "yellow_red_blue",     // bottom-right (row 0, col 2)
-	Back_0_1_0: "yellow_green",        // middle-left (row 1, col 0)
-	Back_0_1_1: "yellow",              // center (row 1, col 1)
-	Back_0_1_2: "yellow_blue",         // middle-right (row 1, col 2)
-	Back_0_2_0: "yellow_green_orange", // top-left (row 2, col 0)
-	Back_0_2_1: "yellow_orange",       // top-center (row 2, col 1)
-	Back_0_2_2: "yellow_blue_orange",  // top-right (row 2, col 2)
-
-	// Up Face (Blue) ZX
-	Up_0_0_2: "blue_yellow_red",    // bottom-left (row 0, col 0)
-	Up_0_1_2: "blue_yellow",        // bottom-center (row 0, col 1)
-	Up_0_2_2: "blue_yellow_orange", // bottom-right (row 0, col 2)
-	Up_1_0_2: "blue_red",           // middle-left (row 1, col 0)
-	Up_1_1_2: "blue",               // center (row 1, col 1)
-	Up_1_2_2: "blue_orange",        // middle-right (row 1, col 2)
-	Up_2_0_2: "blue_white_red",     // top-left (row 2, col 0)
-	Up_2_1_2: "blue_white",         // top-center (row 2, col 1)
-	Up_2_2_2: "blue_white_orange",  // top-right (row 2, col 2)
-
-	// Down Face (Green) ZX
-	Down_0_0_0: "green_yellow_red",    // bottom-left (row 0, col 0)
-	Down_0_1_0: "green_red",           // bottom-center (row 0, col 1)
-	Down_0_2_0: "green_white_red",     // bottom-left (row 0, col 2)
-	Down_1_0_0: "green_yellow",        // middle-left (row 1, col 0)
-	Down_1_1_0: "green",               // center (row 1, col 1)
-	Down_1_2_0: "green_white",         // middle-right (row 1, col 2)
-	Down_2_0_0: "green_yellow_orange", // top-left (row 2, col 0)
-	Down_2_1_0: "green_orange",        // top-center (row 2, col 1)
-	Down_2_2_0: "green_white_orange",  // top-right (row 2, col 2)
-
-	// Left Face (Red) YX
-	Left_0_0_0: "red_yellow_green", // bottom-left (row 0, col 0)
-	Left_0_0_1: "red_green",        // bottom-center (row 0, col 1)
-	Left_0_0_2: "red_green_white",  // bottom-right (row 0, col 2)
-	Left_1_0_0: "red_yellow",       // middle-left (row 1, col 0)
-	Left_1_0_1: "red",              // center (row 1, col 1)
-	Left_1_0_2: "red_white",        // middle-right (row 1, col 2)
-	Left_2_0_0: "red_blue_yellow",  // top-left (row 2, col 0)
-	Left_2_0_1: "red_blue",         // top-center (row 2, col 1)
-	Left_2_0_2: "red_white_blue",   // top-right (row 2, col 2)
-
-	// Right Face (Orange) YX
-	Right_0_2_0: "orange_yellow_green", // bottom-left (row 0, col 0)
-	Right_0_2_1: "orange_green",        // bottom-center (row 0, col 1)
-	Right_0_2_2: "orange_white_green",  // bottom-right (row 0, col 2)
-	Right_1_2_0: "orange_green",        // middle-left (row 1, col 0)
-	Right_1_2_1: "orange",              // center (row 1, col 1)
-	Right_1_2_2: "orange_blue",         // middle-right (row 1, col 2)
-	Right_2_2_0: "orange_white_green",  // top-left (row 2, col 0)
-	Right_2_2_1: "orange_white",        // top-center (row 2, col 1)
-	Right_2_2_2: "orange_white_blue",   // top-right (row 2, col 2)
+// StickerColorName names each sticker of the solved cube by its color, then the other colors of its piece,
+// e.g. "white_blue_red".
+//
+// Deprecated: use Cube.Sticker for the color of a sticker and PieceName for its piece.
+var StickerColorName = stickerColorNames()
+
+func stickerColorNames() map[StickerIndex]string {
+	names := make(map[StickerIndex]string, StickerCount)
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		// In the solved cube each face shows the Color of the same index
+		colors := []string{colorToName(Color(s.Face()))}
+		for _, face := range outerFaces(s.Position()) {
+			if face != s.Face() {
+				colors = append(colors, colorToName(Color(face)))
+			}
+		}
+		names[s] = strings.Join(colors, "_")
+	}
+	return names
 }
@@ -1,10 +1,126 @@
 package model
 
-import (
-	"strings"
-)
+import "testing"
 
-// Helper function to extract colors from a StickerColorName value
-func extractColors(stickerColor string) []string {
-	return strings.Split(stickerColor, "_")
+func TestStickerIndex_RoundTrip(t *testing.T) {
+	seen := make(map[string]bool, StickerCount)
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		if got := NewStickerIndex(s.Face(), s.Row(), s.Col()); got != s {
+			t.Errorf("NewStickerIndex(%d, %d, %d) = %d, want %d", s.Face(), s.Row(), s.Col(), got, s)
+		}
+		parsed, err := ParseSticker(s.String())
+		if err != nil || parsed != s {
+			t.Errorf("ParseSticker(%q) = %d, %v, want %d", s.String(), parsed, err, s)
+		}
+		seen[s.String()] = true
+	}
+	if len(seen) != StickerCount {
+		t.Errorf("got %d distinct sticker names, want %d", len(seen), StickerCount)
+	}
+}
+
+func TestStickerIndex_Names(t *testing.T) {
+	tests := []struct {
+		name string
+		face FaceIndex
+		row  int
+		col  int
+		pos  CubeCoordinate
+	}{
+		{"F1", Front, 0, 0, CubeCoordinate{X: 2, Y: 2, Z: 0}},
+		{"U5", Up, 1, 1, CubeCoordinate{X: 1, Y: 2, Z: 1}},
+		{"U7", Up, 2, 0, CubeCoordinate{X: 2, Y: 2, Z: 0}},
+		{"R3", Right, 0, 2, CubeCoordinate{X: 0, Y: 2, Z: 2}},
+		{"D9", Down, 2, 2, CubeCoordinate{X: 0, Y: 0, Z: 2}},
+	}
+	for _, tt := range tests {
+		s, err := ParseSticker(tt.name)
+		if err != nil {
+			t.Fatalf("ParseSticker(%q) failed: %v", tt.name, err)
+		}
+		if s.Face() != tt.face || s.Row() != tt.row || s.Col() != tt.col {
+			t.Errorf("%s is on face %d at %d,%d, want face %d at %d,%d", tt.name, s.Face(), s.Row(), s.Col(), tt.face, tt.row, tt.col)
+		}
+		if s.Position() != tt.pos {
+			t.Errorf("%s is on the cubie at %v, want %v", tt.name, s.Position(), tt.pos)
+		}
+	}
+
+	for _, name := range []string{"", "U", "U0", "X5", "U10"} {
+		if _, err := ParseSticker(name); err == nil {
+			t.Errorf("expected an error for %q", name)
+		}
+	}
+}
+
+func TestCube_Sticker(t *testing.T) {
+	c := NewCube()
+	moves, _ := ParseAlgorithm("R")
+	c.ApplyMoves(moves)
+
+	// Each sticker agrees with the face grids
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		color, err := c.Sticker(s)
+		if err != nil {
+			t.Fatalf("Sticker(%s) failed: %v", s, err)
+		}
+		if want := c.Face(s.Face())[s.Row()][s.Col()]; color != want {
+			t.Errorf("Sticker(%s) = %d, want %d", s, color, want)
+		}
+	}
+
+	// R brings the Down color to the right column of the Front face
+	if color, _ := c.Sticker(NewStickerIndex(Front, 0, 2)); color != Green {
+		t.Errorf("F3 = %d, want green", color)
+	}
+
+	if _, err := c.Sticker(StickerCount); err == nil {
+		t.Error("expected an error for an out of range sticker")
+	}
+}
+
+func TestCube_FaceNames(t *testing.T) {
+	scheme := ColorSchemes["japanese"]
+	faces := NewCube().FaceNames(scheme)
+	if len(faces) != 6 {
+		t.Fatalf("got %d faces, want 6", len(faces))
+	}
+	for face := Front; face <= Down; face++ {
+		want := scheme.Color(Color(face)).Name
+		for _, row := range faces[faceName(face)] {
+			for _, name := range row {
+				if name != want {
+					t.Errorf("%s face shows %s, want %s", faceName(face), name, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDeprecatedStickerNames(t *testing.T) {
+	tests := []struct {
+		sticker StickerIndex
+		face    FaceIndex
+		pos     CubeCoordinate
+	}{
+		{Front_2_0_0, Front, CubeCoordinate{X: 2, Y: 0, Z: 0}},
+		{Back_0_0_1, Back, CubeCoordinate{X: 0, Y: 1, Z: 0}},
+		{Up_2_0_2, Up, CubeCoordinate{X: 2, Y: 2, Z: 0}},
+		{Down_0_2_0, Down, CubeCoordinate{X: 2, Y: 0, Z: 0}},
+		{Left_2_0_1, Left, CubeCoordinate{X: 1, Y: 2, Z: 0}},
+		{Right_0_2_2, Right, CubeCoordinate{X: 2, Y: 0, Z: 2}},
+	}
+	for _, tt := range tests {
+		if tt.sticker.Face() != tt.face || tt.sticker.Position() != tt.pos {
+			t.Errorf("sticker %s is on the %s face at %v, want the %s face at %v",
+				tt.sticker, faceName(tt.sticker.Face()), tt.sticker.Position(), faceName(tt.face), tt.pos)
+		}
+	}
+
+	if name := StickerColorName[Front_2_0_0]; name != "white_red_green" {
+		t.Errorf("StickerColorName[Front_2_0_0] = %q, want white_red_green", name)
+	}
+	if len(StickerColorName) != StickerCount || FaceColorName[Up] != "blue" {
+		t.Errorf("got %d sticker names and an Up face named %q", len(StickerColorName), FaceColorName[Up])
+	}
 }
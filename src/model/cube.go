@@ -18,6 +18,7 @@ func init() {
 // ResetCube resets the cube to its initial state
 func ResetCube() {
 	SharedCube = NewCube()
+	RestartHistory()
 }
 
 // -------------------------------------------
@@ -40,6 +41,22 @@ func NewCube() *Cube {
 	return cube
 }
 
+// Clone returns a deep copy of the cube, so that the copy can be turned
+// without affecting the original
+func (c *Cube) Clone() *Cube {
+	clone := &Cube{}
+	for x := range 3 {
+		for y := range 3 {
+			for z := range 3 {
+				if cubie := c.Cubies[x][y][z]; cubie != nil {
+					clone.Cubies[x][y][z] = cubie.Clone()
+				}
+			}
+		}
+	}
+	return clone
+}
+
 // RotateAxis rotates a slice of the cube around a specified axis
 func (c *Cube) RotateAxis(axis CubeCoordinate, clockwise TurningDirection) {
 	// copies the layer of the cube to a matrix
@@ -115,7 +132,7 @@ func (c *Cube) ToReadableJSON() (string, error) {
 	return string(jsonBytes), nil
 }
 
-// colorToName maps a Color to its string name
+// colorToName maps a Color to its name, which identifies it whatever the color scheme in use
 func colorToName(color Color) string {
 	switch color {
 	case White:
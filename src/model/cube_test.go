@@ -148,3 +148,21 @@ func TestToReadableJSON_Example(t *testing.T) {
 		t.Errorf("Expected %s, got %s", StartCubeString, jsonStr)
 	}
 }
+
+func TestCube_Clone(t *testing.T) {
+	cube := NewCube()
+	cube.RotateAxis(FrontAxis, Clockwise)
+	want, _ := cube.ToReadableJSON()
+
+	clone := cube.Clone()
+	clone.RotateAxis(UpAxis, Clockwise)
+
+	got, _ := cube.ToReadableJSON()
+	if got != want {
+		t.Errorf("Turning a clone changed the original cube: got %s, want %s", got, want)
+	}
+	cloned, _ := clone.ToReadableJSON()
+	if cloned == want {
+		t.Errorf("Clone was not turned")
+	}
+}
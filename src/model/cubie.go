@@ -1,5 +1,7 @@
 package model
 
+import "maps"
+
 // ------------------------------------------
 // Cubie represents a single piece of the Rubik's Cube with colors on its faces.
 // ------------------------------------------
@@ -7,7 +9,7 @@ type Cubie struct {
 	Colors map[FaceIndex]Color
 }
 
-// createTestCubie creates a new cubie with the standard color setup for testing
+// NewCubie creates a cubie colored as in the solved cube; the color scheme only changes how the colors look
 func NewCubie() *Cubie {
 	return createCubie(White, Orange, Yellow, Red, Blue, Green)
 }
@@ -26,6 +28,11 @@ func createCubie(front, right, back, left, up, down Color) *Cubie {
 	}
 }
 
+// Clone returns a copy of the cubie with its own color map
+func (cu *Cubie) Clone() *Cubie {
+	return &Cubie{Colors: maps.Clone(cu.Colors)}
+}
+
 /*
 X-Axis Faces:
 Front face is at X=1 (positive X-axis) white
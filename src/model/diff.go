@@ -0,0 +1,79 @@
+package model
+
+// PieceDiff is a corner or an edge that is not where the other cube has it
+type PieceDiff struct {
+	Piece       string `json:"piece"`           // colors of the piece, e.g. "white-orange-blue"
+	Kind        string `json:"kind"`            // corner or edge
+	Slot        string `json:"slot"`            // slot of the piece in the first cube
+	Target      string `json:"target"`          // slot of the piece in the second cube
+	Twist       int    `json:"twist,omitempty"` // for a piece in its target slot, the change of orientation as in PieceLocation
+	Misplaced   bool   `json:"misplaced"`       // in another slot than its target
+	Misoriented bool   `json:"misoriented"`     // in its target slot, but twisted or flipped
+}
+
+// CubeDiff compares the pieces of two cubes
+type CubeDiff struct {
+	Pieces      []PieceDiff `json:"pieces"`      // pieces that differ, corners first
+	Misplaced   int         `json:"misplaced"`   // pieces in another slot
+	Misoriented int         `json:"misoriented"` // pieces in the right slot but turned
+	Matching    int         `json:"matching"`    // pieces placed and oriented alike in both cubes
+	Total       int         `json:"total"`       // corners and edges, 20
+	Stickers    int         `json:"stickers"`    // stickers of a different color, out of StickerCount
+}
+
+// Equal reports whether the two cubes show the same stickers
+func (d CubeDiff) Equal() bool {
+	return d.Stickers == 0
+}
+
+// Diff tells which corners and edges of a are misplaced or misoriented compared to b,
+// for example the current cube against the solved one. Matching counts the pieces
+// already in place, a measure of the progress from a toward b.
+func Diff(a, b *Cube) (CubeDiff, error) {
+	from, err := a.Pieces()
+	if err != nil {
+		return CubeDiff{}, err
+	}
+	to, err := b.Pieces()
+	if err != nil {
+		return CubeDiff{}, err
+	}
+
+	diff := CubeDiff{Pieces: []PieceDiff{}, Total: len(from)}
+	// Both lists follow the home slots of the pieces
+	for i, piece := range from {
+		target := to[i]
+		d := PieceDiff{
+			Piece:  piece.Piece,
+			Kind:   piece.Kind,
+			Slot:   piece.Slot,
+			Target: target.Slot,
+		}
+		switch {
+		case piece.Slot != target.Slot:
+			d.Misplaced = true
+			diff.Misplaced++
+		case piece.Orientation != target.Orientation:
+			twists := 3
+			if piece.Kind == "edge" {
+				twists = 2
+			}
+			d.Twist = ((target.Orientation-piece.Orientation)%twists + twists) % twists
+			d.Misoriented = true
+			diff.Misoriented++
+		default:
+			diff.Matching++
+			continue
+		}
+		diff.Pieces = append(diff.Pieces, d)
+	}
+
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		colorA, errA := a.Sticker(s)
+		colorB, errB := b.Sticker(s)
+		if errA != nil || errB != nil || colorA != colorB {
+			diff.Stickers++
+		}
+	}
+	return diff, nil
+}
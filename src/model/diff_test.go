@@ -0,0 +1,55 @@
+package model
+
+import "testing"
+
+func TestDiff_SameCube(t *testing.T) {
+	cube := NewCube()
+	cube.Scramble(20)
+	diff, err := Diff(cube, cube.Clone())
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if !diff.Equal() || diff.Matching != 20 || diff.Total != 20 || len(diff.Pieces) != 0 {
+		t.Errorf("Diff of a cube with itself = %+v, want 20 matching pieces", diff)
+	}
+}
+
+func TestDiff_MisplacedPieces(t *testing.T) {
+	cube := NewCube()
+	moves, _ := ParseAlgorithm("R")
+	cube.ApplyMoves(moves)
+
+	diff, err := Diff(cube, NewCube())
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	// R moves four corners and four edges, and changes the 12 stickers around the Right face
+	if diff.Misplaced != 8 || diff.Misoriented != 0 || diff.Matching != 12 || diff.Stickers != 12 {
+		t.Errorf("Diff after R = %d misplaced, %d misoriented, %d matching, %d stickers; want 8, 0, 12, 12",
+			diff.Misplaced, diff.Misoriented, diff.Matching, diff.Stickers)
+	}
+	for _, piece := range diff.Pieces {
+		if piece.Piece == "white-orange-blue" && (piece.Slot != "UBR" || piece.Target != "URF") {
+			t.Errorf("white-orange-blue corner is in %s for %s, want UBR for URF", piece.Slot, piece.Target)
+		}
+	}
+}
+
+func TestDiff_TwistedCorners(t *testing.T) {
+	// Twist the URF corner one way and the UFL corner the other way
+	cube := NewCube()
+	moves, _ := ParseAlgorithm("R' D' R D R' D' R D U R' D' R D R' D' R D R' D' R D R' D' R D U'")
+	cube.ApplyMoves(moves)
+
+	diff, err := Diff(cube, NewCube())
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if diff.Misplaced != 0 || diff.Misoriented != 2 || diff.Matching != 18 || diff.Stickers != 6 {
+		t.Fatalf("Diff = %d misplaced, %d misoriented, %d matching, %d stickers; want 0, 2, 18, 6",
+			diff.Misplaced, diff.Misoriented, diff.Matching, diff.Stickers)
+	}
+	if twists := diff.Pieces[0].Twist + diff.Pieces[1].Twist; twists != 3 {
+		t.Errorf("corners twisted by %d and %d, want opposite twists", diff.Pieces[0].Twist, diff.Pieces[1].Twist)
+	}
+}
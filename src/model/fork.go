@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrForkNotFound is returned when no fork exists with the requested id
+var ErrForkNotFound = errors.New("fork not found")
+
+// Fork is a scratch copy of the shared cube on which moves can be tried
+// without changing what is displayed to everyone else
+type Fork struct {
+	ID    string `json:"id"`
+	Moves []Move `json:"moves"`
+	cube  *Cube
+}
+
+var (
+	forks       = make(map[string]*Fork)
+	forksLock   sync.Mutex
+	forkCounter int
+)
+
+// CreateFork clones the shared cube into a new fork
+func CreateFork() Fork {
+	forksLock.Lock()
+	defer forksLock.Unlock()
+
+	forkCounter++
+	fork := &Fork{
+		ID:    fmt.Sprintf("fork-%d", forkCounter),
+		Moves: []Move{},
+		cube:  SharedCube.Clone(),
+	}
+	forks[fork.ID] = fork
+	return fork.snapshot()
+}
+
+// GetFork returns a snapshot of the fork with the given id
+func GetFork(id string) (Fork, error) {
+	forksLock.Lock()
+	defer forksLock.Unlock()
+
+	fork, ok := forks[id]
+	if !ok {
+		return Fork{}, ErrForkNotFound
+	}
+	return fork.snapshot(), nil
+}
+
+// ApplyToFork applies the moves to the fork, leaving it untouched if one of them is invalid
+func ApplyToFork(id string, moves []Move) (Fork, error) {
+	forksLock.Lock()
+	defer forksLock.Unlock()
+
+	fork, ok := forks[id]
+	if !ok {
+		return Fork{}, ErrForkNotFound
+	}
+	if err := fork.cube.ApplyMoves(moves); err != nil {
+		return Fork{}, err
+	}
+	fork.Moves = append(fork.Moves, moves...)
+	return fork.snapshot(), nil
+}
+
+// CommitFork applies the moves recorded on the fork to the shared cube and removes the fork.
+// The moves are replayed rather than the state copied, so turns made on the shared cube
+// since the fork was created are kept.
+func CommitFork(id string) ([]Move, error) {
+	forksLock.Lock()
+	defer forksLock.Unlock()
+
+	fork, ok := forks[id]
+	if !ok {
+		return nil, ErrForkNotFound
+	}
+	if err := SharedCube.ApplyMoves(fork.Moves); err != nil {
+		return nil, err
+	}
+	RecordMoves(fork.Moves...)
+	delete(forks, id)
+	return fork.Moves, nil
+}
+
+// DiscardFork removes the fork without touching the shared cube
+func DiscardFork(id string) error {
+	forksLock.Lock()
+	defer forksLock.Unlock()
+
+	if _, ok := forks[id]; !ok {
+		return ErrForkNotFound
+	}
+	delete(forks, id)
+	return nil
+}
+
+// Cube returns a copy of the fork's cube
+func (f Fork) Cube() *Cube {
+	return f.cube.Clone()
+}
+
+// snapshot copies the fork so that it can be used outside of the lock
+func (f *Fork) snapshot() Fork {
+	return Fork{
+		ID:    f.ID,
+		Moves: slices.Clone(f.Moves),
+		cube:  f.cube.Clone(),
+	}
+}
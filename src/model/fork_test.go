@@ -0,0 +1,87 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFork_ApplyDoesNotTouchSharedCube(t *testing.T) {
+	ResetCube()
+	fork := CreateFork()
+
+	moves := []Move{{Axis: "x", Layer: 1, Direction: 1}, {Axis: "y", Layer: 1, Direction: -1}}
+	fork, err := ApplyToFork(fork.ID, moves)
+	if err != nil {
+		t.Fatalf("ApplyToFork failed: %v", err)
+	}
+	if len(fork.Moves) != 2 {
+		t.Errorf("Fork recorded %d moves, want 2", len(fork.Moves))
+	}
+
+	shared, _ := SharedCube.ToReadableJSON()
+	if shared != StartCubeString {
+		t.Errorf("Applying moves to a fork changed the shared cube: %s", shared)
+	}
+
+	want := NewCube()
+	want.ApplyMoves(moves)
+	wantJSON, _ := want.ToReadableJSON()
+	gotJSON, _ := fork.Cube().ToReadableJSON()
+	if gotJSON != wantJSON {
+		t.Errorf("Fork cube = %s, want %s", gotJSON, wantJSON)
+	}
+
+	// The cube of a fork is a copy
+	fork.Cube().ApplyMoves(moves)
+	if gotJSON, _ := fork.Cube().ToReadableJSON(); gotJSON != wantJSON {
+		t.Errorf("Turning the cube of a fork changed the fork: %s", gotJSON)
+	}
+}
+
+func TestFork_Commit(t *testing.T) {
+	ResetCube()
+	fork := CreateFork()
+	moves := []Move{{Axis: "z", Layer: 1, Direction: 1}, {Axis: "y", Layer: -1, Direction: 1}}
+	if _, err := ApplyToFork(fork.ID, moves); err != nil {
+		t.Fatalf("ApplyToFork failed: %v", err)
+	}
+
+	committed, err := CommitFork(fork.ID)
+	if err != nil {
+		t.Fatalf("CommitFork failed: %v", err)
+	}
+	if len(committed) != len(moves) {
+		t.Errorf("CommitFork returned %d moves, want %d", len(committed), len(moves))
+	}
+
+	want := NewCube()
+	want.ApplyMoves(moves)
+	wantJSON, _ := want.ToReadableJSON()
+	gotJSON, _ := SharedCube.ToReadableJSON()
+	if gotJSON != wantJSON {
+		t.Errorf("Shared cube after commit = %s, want %s", gotJSON, wantJSON)
+	}
+
+	if _, err := GetFork(fork.ID); !errors.Is(err, ErrForkNotFound) {
+		t.Errorf("Committed fork should be removed, got error %v", err)
+	}
+}
+
+func TestFork_Discard(t *testing.T) {
+	ResetCube()
+	fork := CreateFork()
+	if _, err := ApplyToFork(fork.ID, []Move{{Axis: "x", Layer: 1, Direction: 1}}); err != nil {
+		t.Fatalf("ApplyToFork failed: %v", err)
+	}
+	if err := DiscardFork(fork.ID); err != nil {
+		t.Fatalf("DiscardFork failed: %v", err)
+	}
+
+	shared, _ := SharedCube.ToReadableJSON()
+	if shared != StartCubeString {
+		t.Errorf("Discarding a fork changed the shared cube: %s", shared)
+	}
+	if err := DiscardFork(fork.ID); !errors.Is(err, ErrForkNotFound) {
+		t.Errorf("Discarding twice error = %v, want %v", err, ErrForkNotFound)
+	}
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SolvedGoal is the name of the default goal, the solved cube
+const SolvedGoal = "solved"
+
+// CustomGoal names a goal given as a full state rather than as a pattern
+const CustomGoal = "custom"
+
+// Patterns lists pretty patterns by name, as the algorithm that makes them from the solved cube
+var Patterns = map[string]string{
+	"checkerboard": "R2 L2 U2 D2 F2 B2",
+	"superflip":    "U R2 F B R B2 R U2 L B2 R U' D' R2 F R' L B2 U2 F2",
+	"cube-in-cube": "F L F U' R U F2 L2 U' L' B D' B' L2 U",
+	"tetris":       "L R F B U' D' L' R'",
+}
+
+// PatternNames returns the names of the patterns in alphabetical order, after the solved goal
+func PatternNames() []string {
+	names := make([]string, 0, len(Patterns))
+	for name := range Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{SolvedGoal}, names...)
+}
+
+// PatternCube returns the cube showing the named pattern
+func PatternCube(name string) (*Cube, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	cube := NewCube()
+	if name == SolvedGoal {
+		return cube, nil
+	}
+	algorithm, ok := Patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown pattern %q, expected one of %s", name, strings.Join(PatternNames(), ", "))
+	}
+	moves, err := ParseAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := cube.ApplyMoves(moves); err != nil {
+		return nil, err
+	}
+	return cube, nil
+}
+
+// GoalStatus tells how close a cube is to the goal
+type GoalStatus struct {
+	Name     string  `json:"name"`     // pattern of the goal, or custom
+	Matching int     `json:"matching"` // stickers of the goal color
+	Total    int     `json:"total"`    // StickerCount
+	Percent  float64 `json:"percent"`  // share of matching stickers, rounded to a tenth
+	Reached  bool    `json:"reached"`
+}
+
+var (
+	goalCube    = NewCube()
+	goalName    = SolvedGoal
+	goalReached bool
+	goalLock    sync.Mutex
+)
+
+// SetGoal makes the given cube the goal under the given name, or the solved cube when it is nil
+func SetGoal(name string, cube *Cube) {
+	if cube == nil {
+		name, cube = SolvedGoal, NewCube()
+	}
+
+	goalLock.Lock()
+	defer goalLock.Unlock()
+	goalName = name
+	goalCube = cube.Clone()
+	goalReached = false
+}
+
+// SetGoalPattern makes the named pattern the goal
+func SetGoalPattern(name string) error {
+	cube, err := PatternCube(name)
+	if err != nil {
+		return err
+	}
+	SetGoal(strings.ToLower(strings.TrimSpace(name)), cube)
+	return nil
+}
+
+// Goal returns a copy of the goal cube and its name
+func Goal() (*Cube, string) {
+	goalLock.Lock()
+	defer goalLock.Unlock()
+	return goalCube.Clone(), goalName
+}
+
+// GoalProgress compares a cube with the goal, sticker by sticker
+func GoalProgress(c *Cube) GoalStatus {
+	goalLock.Lock()
+	defer goalLock.Unlock()
+	return goalProgress(c)
+}
+
+// UpdateGoal compares a cube with the goal and reports whether it has just reached it:
+// reached is only true for the first cube matching the goal since it was set or last left
+func UpdateGoal(c *Cube) (status GoalStatus, reached bool) {
+	goalLock.Lock()
+	defer goalLock.Unlock()
+
+	status = goalProgress(c)
+	reached = status.Reached && !goalReached
+	goalReached = status.Reached
+	return status, reached
+}
+
+// goalProgress compares a cube with the goal, with the lock held
+func goalProgress(c *Cube) GoalStatus {
+	status := GoalStatus{Name: goalName, Total: StickerCount}
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		color, err := c.Sticker(s)
+		want, _ := goalCube.Sticker(s)
+		if err == nil && color == want {
+			status.Matching++
+		}
+	}
+	status.Percent = math.Round(float64(status.Matching)*1000/StickerCount) / 10
+	status.Reached = status.Matching == StickerCount
+	return status
+}
@@ -0,0 +1,71 @@
+package model
+
+import "testing"
+
+func TestPatterns_AreValid(t *testing.T) {
+	for _, name := range PatternNames() {
+		cube, err := PatternCube(name)
+		if err != nil {
+			t.Fatalf("PatternCube(%q) failed: %v", name, err)
+		}
+		if _, err := cube.PieceState(); err != nil {
+			t.Errorf("%s pattern is not a valid cube: %v", name, err)
+		}
+	}
+	if _, err := PatternCube("spiral"); err == nil {
+		t.Error("expected an error for an unknown pattern")
+	}
+}
+
+func TestPatterns_Superflip(t *testing.T) {
+	cube, _ := PatternCube("superflip")
+	diff, err := Diff(cube, NewCube())
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	// Every edge is flipped in its slot, and the corners are solved
+	if diff.Misoriented != 12 || diff.Misplaced != 0 {
+		t.Errorf("superflip has %d misoriented and %d misplaced pieces, want 12 and 0", diff.Misoriented, diff.Misplaced)
+	}
+}
+
+func TestPatterns_Checkerboard(t *testing.T) {
+	cube, _ := PatternCube("checkerboard")
+	for face := Front; face <= Down; face++ {
+		colors := cube.Face(face)
+		center := colors[1][1]
+		for row := range 3 {
+			for col := range 3 {
+				if same := colors[row][col] == center; same != ((row+col)%2 == 0) {
+					t.Errorf("%s face at %d,%d breaks the checkerboard", faceName(face), row, col)
+				}
+			}
+		}
+	}
+}
+
+func TestUpdateGoal_ReportsReachingOnce(t *testing.T) {
+	defer SetGoal(SolvedGoal, nil)
+	if err := SetGoalPattern("checkerboard"); err != nil {
+		t.Fatalf("SetGoalPattern failed: %v", err)
+	}
+
+	cube := NewCube()
+	status, reached := UpdateGoal(cube)
+	if reached || status.Reached || status.Name != "checkerboard" {
+		t.Fatalf("solved cube against the checkerboard = %+v, %v", status, reached)
+	}
+	// The checkerboard keeps the centers and the corners of every face
+	if status.Matching != 30 || status.Percent != 55.6 {
+		t.Errorf("solved cube matches %d stickers (%.1f%%), want 30 (55.6%%)", status.Matching, status.Percent)
+	}
+
+	moves, _ := ParseAlgorithm(Patterns["checkerboard"])
+	cube.ApplyMoves(moves)
+	if status, reached := UpdateGoal(cube); !reached || !status.Reached || status.Percent != 100 {
+		t.Errorf("checkerboard cube = %+v, %v; want the goal just reached", status, reached)
+	}
+	if _, reached := UpdateGoal(cube); reached {
+		t.Error("the goal was reported reached twice")
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"slices"
+	"sync"
+)
+
+// History holds the moves applied to the shared cube since it was last reset, scrambled
+// or replaced, together with the state they started from, so that they can be replayed
+type History struct {
+	Start     [3][3][3]*Cubie `json:"start"`
+	Moves     []Move          `json:"moves"`
+	Algorithm string          `json:"algorithm"`
+}
+
+var (
+	historyStart = NewCube()
+	historyMoves []Move
+	historyLock  sync.Mutex
+)
+
+// RecordMoves appends moves applied to the shared cube to its history
+func RecordMoves(moves ...Move) {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	historyMoves = append(historyMoves, moves...)
+}
+
+// RestartHistory clears the history, which now starts from the current shared cube.
+// It is called whenever the shared cube changes other than by recorded moves.
+func RestartHistory() {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	historyStart = SharedCube.Clone()
+	historyMoves = nil
+}
+
+// MoveHistory returns a copy of the shared cube's history
+func MoveHistory() History {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	moves := slices.Clone(historyMoves)
+	if moves == nil {
+		moves = []Move{}
+	}
+	return History{
+		Start:     historyStart.Clone().Cubies,
+		Moves:     moves,
+		Algorithm: FormatAlgorithm(moves),
+	}
+}
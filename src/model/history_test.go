@@ -0,0 +1,53 @@
+package model
+
+import "testing"
+
+func TestHistory_RecordAndRestart(t *testing.T) {
+	ResetCube()
+	if history := MoveHistory(); len(history.Moves) != 0 {
+		t.Fatalf("History after reset has %d moves, want 0", len(history.Moves))
+	}
+
+	moves, _ := ParseAlgorithm("R U R'")
+	SharedCube.ApplyMoves(moves)
+	RecordMoves(moves...)
+
+	history := MoveHistory()
+	if history.Algorithm != "R U R'" {
+		t.Errorf("History algorithm = %q, want %q", history.Algorithm, "R U R'")
+	}
+
+	// Replaying the history from its start gives the shared cube
+	replayed := &Cube{Cubies: history.Start}
+	replayed.ApplyMoves(history.Moves)
+	want, _ := SharedCube.ToReadableJSON()
+	if got, _ := replayed.ToReadableJSON(); got != want {
+		t.Errorf("Replayed history = %s, want %s", got, want)
+	}
+
+	// A scramble starts a new history from the scrambled state
+	SharedCube.Scramble(10)
+	RestartHistory()
+	history = MoveHistory()
+	if len(history.Moves) != 0 {
+		t.Errorf("History after restart has %d moves, want 0", len(history.Moves))
+	}
+	want, _ = SharedCube.ToReadableJSON()
+	if got, _ := (&Cube{Cubies: history.Start}).ToReadableJSON(); got != want {
+		t.Error("Restarted history should start from the current shared cube")
+	}
+	ResetCube()
+}
+
+func TestHistory_CommitFork(t *testing.T) {
+	ResetCube()
+	fork := CreateFork()
+	moves := []Move{{Axis: "y", Layer: 1, Direction: 1}}
+	ApplyToFork(fork.ID, moves)
+	CommitFork(fork.ID)
+
+	if history := MoveHistory(); len(history.Moves) != 1 || history.Moves[0] != moves[0] {
+		t.Errorf("History after commit = %v, want %v", history.Moves, moves)
+	}
+	ResetCube()
+}
@@ -0,0 +1,277 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// LastLayerAlgorithm is a case of the last layer with an algorithm solving it. The algorithms are
+// the usual ones written with face turns only, as ParseAlgorithm reads them: their wide and slice
+// turns and cube rotations are replaced by the face turns they amount to with the centers kept still.
+type LastLayerAlgorithm struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+}
+
+// OLLAlgorithms orients the last layer once the first two layers are solved, numbered as usual
+var OLLAlgorithms = []LastLayerAlgorithm{
+	{"OLL 1", "R U2 R2 F R F' U2 R' F R F'"},
+	{"OLL 2", "F R U R' U' F' B U L U' L' B'"},
+	{"OLL 3", "B U L U' L' B' U' F R U R' U' F'"},
+	{"OLL 4", "B U L U' L' B' U F R U R' U' F'"},
+	{"OLL 5", "L' B2 R B R' B L"},
+	{"OLL 6", "L F2 R' F' R F' L'"},
+	{"OLL 7", "L F R' F R F2 L'"},
+	{"OLL 8", "R' F' L F' L' F2 R"},
+	{"OLL 9", "R U R' U' R' F R2 U R' U' F'"},
+	{"OLL 10", "R U R' U R' F R F' R U2 R'"},
+	{"OLL 11", "L F R' F R' D R D' R F2 L'"},
+	{"OLL 12", "R2 L F' R F' R' F2 R F' R L'"},
+	{"OLL 13", "F U R U' R2 F' R U R U' R'"},
+	{"OLL 14", "R' F R U R' F' R F U' F'"},
+	{"OLL 15", "R' F' R L' U' L U R' F R"},
+	{"OLL 16", "L F L' R U R' U' L F' L'"},
+	{"OLL 17", "R U R' U R' F R F' U2 R' F R F'"},
+	{"OLL 18", "L F R' F R F2 L2 B' R B' R' B2 L"},
+	{"OLL 19", "R L' B R B R' B' R2 L F R F'"},
+	{"OLL 20", "L F R' F' R2 L2 B R B' R' B' R' L"},
+	{"OLL 21", "R U2 R' U' R U R' U' R U' R'"},
+	{"OLL 22", "R U2 R2 U' R2 U' R2 U2 R"},
+	{"OLL 23", "R2 D' R U2 R' D R U2 R"},
+	{"OLL 24", "L F R' F' L' F R F'"},
+	{"OLL 25", "F' L F R' F' L' F R"},
+	{"OLL 26", "R U2 R' U' R U' R'"},
+	{"OLL 27", "R U R' U R U2 R'"},
+	{"OLL 28", "L F R' F' R L' U R U' R'"},
+	{"OLL 29", "R U R' U' R U' R' F' U' F R U R'"},
+	{"OLL 30", "F R' F R2 U' R' U' R U R' F2"},
+	{"OLL 31", "R' U' F U R U' R' F' R"},
+	{"OLL 32", "L U F' U' L' U L F L'"},
+	{"OLL 33", "R U R' U' R' F R F'"},
+	{"OLL 34", "R U R2 U' R' F R U R U' F'"},
+	{"OLL 35", "R U2 R2 F R F' R U2 R'"},
+	{"OLL 36", "L' U' L U' L' U L U L F' L' F"},
+	{"OLL 37", "F R' F' R U R U' R'"},
+	{"OLL 38", "R U R' U R U' R' U' R' F R F'"},
+	{"OLL 39", "L F' L' U' L U F U' L'"},
+	{"OLL 40", "R' F R U R' U' F' U R"},
+	{"OLL 41", "R U R' U R U2 R' F R U R' U' F'"},
+	{"OLL 42", "R' U' R U' R' U2 R F R U R' U' F'"},
+	{"OLL 43", "R' U' F' U F R"},
+	{"OLL 44", "F U R U' R' F'"},
+	{"OLL 45", "F R U R' U' F'"},
+	{"OLL 46", "R' U' R' F R F' U R"},
+	{"OLL 47", "F' L' U' L U L' U' L U F"},
+	{"OLL 48", "F R U R' U' R U R' U' F'"},
+	{"OLL 49", "L F' L2 B L2 F L2 B' L"},
+	{"OLL 50", "L' B L2 F' L2 B' L2 F L'"},
+	{"OLL 51", "F U R U' R' U R U' R' F'"},
+	{"OLL 52", "R U R' U R U' B U' B' R'"},
+	{"OLL 53", "R' F2 L F L' F' L F L' F R"},
+	{"OLL 54", "L F2 R' F' R F R' F' R F' L'"},
+	{"OLL 55", "R U2 R2 U' R U' R' U2 F R F'"},
+	{"OLL 56", "F R U R' U' R F' L F R' F' L'"},
+	{"OLL 57", "R U R' U' R' L F R F' L'"},
+}
+
+// PLLAlgorithms permutes the last layer once it is oriented
+var PLLAlgorithms = []LastLayerAlgorithm{
+	{"Aa", "R' F R' B2 R F' R' B2 R2"},
+	{"Ab", "R2 B2 R F R' B2 R F' R"},
+	{"E", "R B' R' F R B R' F' R B R' F R B' R' F'"},
+	{"F", "R' U' F' R U R' U' R' F R2 U' R' U' R U R' U R"},
+	{"Ga", "R2 U R' U R' U' R U' R2 U' D R' U R D'"},
+	{"Gb", "R' U' R U D' R2 U R' U R U' R U' R2 D"},
+	{"Gc", "R2 U' R U' R U R' U R2 U D' R U' R' D"},
+	{"Gd", "R U R' U' D R2 U' R U' R' U R' U R2 D'"},
+	{"H", "R2 L2 D R2 L2 U2 R2 L2 D R2 L2"},
+	{"Ja", "R' U L' U2 R U' R' U2 R L"},
+	{"Jb", "R U R' F' R U R' U' R' F R2 U' R'"},
+	{"Na", "R U R' U R U R' F' R U R' U' R' F R2 U' R' U2 R U' R'"},
+	{"Nb", "R' U R U' R' F' U' F R U R' F R' F' R U' R"},
+	{"Ra", "R U' R' U' R U R D R' U' R D' R' U2 R'"},
+	{"Rb", "R2 F R U R U' R' F' R U2 R' U2 R"},
+	{"T", "R U R' U' R' F R2 U' R' U' R U R' F'"},
+	{"Ua", "R2 L2 D R L' F2 R' L D R2 L2"},
+	{"Ub", "R2 L2 D' R L' F2 R' L D' R2 L2"},
+	{"V", "R' U R' U' B' R' B2 U' B' U B' R B R"},
+	{"Y", "F R U' R' U' R U R' F' R U R' U' R' F R F'"},
+	{"Z", "R' L F R2 L2 B R2 L2 F R' L D2 R2 L2"},
+}
+
+// LastLayerCase tells which case the last layer is in and how to solve it
+type LastLayerCase struct {
+	Step      string `json:"step"`                // oll, pll, or solved
+	Case      string `json:"case,omitempty"`      // e.g. "OLL 27" or "T", "skip" when only a U turn is left
+	AUF       string `json:"auf,omitempty"`       // turn of U before the algorithm
+	Algorithm string `json:"algorithm,omitempty"` // algorithm of the case
+	Solution  string `json:"solution,omitempty"`  // the AUF, the algorithm, then the U turn ending a PLL
+}
+
+// ErrF2LNotSolved is returned when looking for the last layer case of a cube before its first two layers are solved
+var ErrF2LNotSolved = errors.New("the first two layers are not solved")
+
+// lastLayerEntry is a state of the last layer: the case it is in and the U turns around the algorithm
+type lastLayerEntry struct {
+	algorithm LastLayerAlgorithm
+	before    int // quarter turns of U before the algorithm
+	after     int // quarter turns of U after it
+}
+
+var (
+	ollCases      map[string]lastLayerEntry
+	pllCases      map[string]lastLayerEntry
+	lastLayerOnce sync.Once
+)
+
+// buildLastLayerCases lists every last layer state each algorithm solves, with the U turns
+// before and after it, from the solved cube turned back through them
+func buildLastLayerCases() {
+	ollCases = make(map[string]lastLayerEntry)
+	pllCases = make(map[string]lastLayerEntry)
+	add := func(cases map[string]lastLayerEntry, algorithms []LastLayerAlgorithm, key func(*Cube) string, afters int) {
+		for _, algorithm := range algorithms {
+			moves, err := ParseAlgorithm(algorithm.Algorithm)
+			if err != nil {
+				panic(fmt.Sprintf("invalid %s algorithm: %v", algorithm.Name, err))
+			}
+			for after := range afters {
+				for before := range 4 {
+					cube := NewCube()
+					turnUp(cube, -after)
+					for i := len(moves) - 1; i >= 0; i-- {
+						cube.ApplyMove(moves[i].Inverse())
+					}
+					turnUp(cube, -before)
+					if _, found := cases[key(cube)]; !found {
+						cases[key(cube)] = lastLayerEntry{algorithm, before, after}
+					}
+				}
+			}
+		}
+	}
+	// The orientation does not depend on where the pieces go after the algorithm
+	add(ollCases, OLLAlgorithms, ollKey, 1)
+	add(pllCases, PLLAlgorithms, pllKey, 4)
+}
+
+// turnUp turns the Up face by the given number of quarter turns, clockwise when positive
+func turnUp(c *Cube, quarters int) {
+	direction := 1
+	if quarters < 0 {
+		direction, quarters = -1, -quarters
+	}
+	for range quarters % 4 {
+		c.ApplyMove(Move{Axis: "y", Layer: 1, Direction: direction})
+	}
+}
+
+// upTurn writes quarter turns of the Up face in notation
+func upTurn(quarters int) string {
+	return [4]string{"", "U", "U2", "U'"}[(quarters%4+4)%4]
+}
+
+// lastLayerStickers lists the stickers of the last layer: the Up face, then the top row of the side faces
+func lastLayerStickers() []StickerIndex {
+	stickers := stickerMask(faceStickers(Up))
+	for _, face := range []FaceIndex{Front, Right, Back, Left} {
+		for col := range 3 {
+			stickers = append(stickers, NewStickerIndex(face, 0, col))
+		}
+	}
+	return stickers
+}
+
+// ollKey writes which stickers of the last layer show the Up color
+func ollKey(c *Cube) string {
+	up, _ := c.Sticker(NewStickerIndex(Up, 1, 1))
+	var key strings.Builder
+	for _, s := range lastLayerStickers() {
+		if color, _ := c.Sticker(s); color == up {
+			key.WriteByte('1')
+		} else {
+			key.WriteByte('0')
+		}
+	}
+	return key.String()
+}
+
+// pllKey writes the colors of the top row of the side faces
+func pllKey(c *Cube) string {
+	var key strings.Builder
+	for _, s := range lastLayerStickers()[9:] {
+		color, _ := c.Sticker(s)
+		key.WriteByte(byte('0' + color))
+	}
+	return key.String()
+}
+
+// stageDone reports whether the cube has reached the named stage
+func stageDone(c *Cube, name string) (bool, error) {
+	stage, err := FindStage(name)
+	if err != nil {
+		return false, err
+	}
+	status, err := stage.Check(c)
+	return status.Done, err
+}
+
+// RecognizeLastLayer finds the OLL case of a cube with its first two layers solved, or its PLL
+// case once the last layer is oriented, up to turns of the Up face
+func (c *Cube) RecognizeLastLayer() (LastLayerCase, error) {
+	lastLayerOnce.Do(buildLastLayerCases)
+
+	if done, err := stageDone(c, "f2l"); err != nil {
+		return LastLayerCase{}, err
+	} else if !done {
+		return LastLayerCase{}, ErrF2LNotSolved
+	}
+
+	if oriented, err := stageDone(c, "oll"); err != nil {
+		return LastLayerCase{}, err
+	} else if !oriented {
+		entry, ok := ollCases[ollKey(c)]
+		if !ok {
+			return LastLayerCase{}, errors.New("the orientation of the last layer is not a case of the library")
+		}
+		return entry.lastLayerCase("oll"), nil
+	}
+
+	if solved, err := stageDone(c, "solved"); err != nil {
+		return LastLayerCase{}, err
+	} else if solved {
+		return LastLayerCase{Step: "solved"}, nil
+	}
+	// Only a turn of the Up face is left
+	for quarters := 1; quarters < 4; quarters++ {
+		turned := c.Clone()
+		turnUp(turned, quarters)
+		if solved, _ := stageDone(turned, "solved"); solved {
+			return LastLayerCase{Step: "pll", Case: "skip", Solution: upTurn(quarters)}, nil
+		}
+	}
+	entry, ok := pllCases[pllKey(c)]
+	if !ok {
+		return LastLayerCase{}, errors.New("the permutation of the last layer is not a case of the library")
+	}
+	return entry.lastLayerCase("pll"), nil
+}
+
+// lastLayerCase describes the case with the U turns around its algorithm
+func (e lastLayerEntry) lastLayerCase(step string) LastLayerCase {
+	var solution []string
+	for _, part := range []string{upTurn(e.before), e.algorithm.Algorithm, upTurn(e.after)} {
+		if part != "" {
+			solution = append(solution, part)
+		}
+	}
+	return LastLayerCase{
+		Step:      step,
+		Case:      e.algorithm.Name,
+		AUF:       upTurn(e.before),
+		Algorithm: e.algorithm.Algorithm,
+		Solution:  strings.Join(solution, " "),
+	}
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// applyAlgorithm applies an algorithm in notation, failing the test when it does not parse
+func applyAlgorithm(t *testing.T, c *Cube, algorithm string) {
+	t.Helper()
+	moves, err := ParseAlgorithm(algorithm)
+	if err != nil {
+		t.Fatalf("ParseAlgorithm(%q) failed: %v", algorithm, err)
+	}
+	c.ApplyMoves(moves)
+}
+
+// caseOf sets up the case an algorithm solves, by turning the solved cube back through it
+func caseOf(t *testing.T, algorithm string) *Cube {
+	t.Helper()
+	moves, err := ParseAlgorithm(algorithm)
+	if err != nil {
+		t.Fatalf("ParseAlgorithm(%q) failed: %v", algorithm, err)
+	}
+	cube := NewCube()
+	for i := len(moves) - 1; i >= 0; i-- {
+		cube.ApplyMove(moves[i].Inverse())
+	}
+	return cube
+}
+
+func TestLastLayer_Library(t *testing.T) {
+	if len(OLLAlgorithms) != 57 || len(PLLAlgorithms) != 21 {
+		t.Fatalf("got %d OLL and %d PLL cases, want 57 and 21", len(OLLAlgorithms), len(PLLAlgorithms))
+	}
+
+	for _, set := range []struct {
+		step       string
+		algorithms []LastLayerAlgorithm
+		done       string
+	}{
+		{"oll", OLLAlgorithms, "oll"},
+		{"pll", PLLAlgorithms, "solved"},
+	} {
+		for _, algorithm := range set.algorithms {
+			cube := caseOf(t, algorithm.Algorithm)
+			if done, _ := stageDone(cube, "f2l"); !done {
+				t.Errorf("%s breaks the first two layers", algorithm.Name)
+				continue
+			}
+
+			// Each case is recognized as itself, so no two algorithms solve the same case
+			found, err := cube.RecognizeLastLayer()
+			if err != nil {
+				t.Errorf("RecognizeLastLayer on %s failed: %v", algorithm.Name, err)
+				continue
+			}
+			if found.Step != set.step || found.Case != algorithm.Name || found.AUF != "" {
+				t.Errorf("%s is recognized as %+v", algorithm.Name, found)
+			}
+
+			// From any angle
+			turnUp(cube, 1)
+			found, _ = cube.RecognizeLastLayer()
+			applyAlgorithm(t, cube, found.Solution)
+			if done, _ := stageDone(cube, set.done); found.Case != algorithm.Name || !done {
+				t.Errorf("%s turned by U is recognized as %s, solved by %q: %s done = %v", algorithm.Name, found.Case, found.Solution, set.done, done)
+			}
+		}
+	}
+}
+
+func TestLastLayer_OLLNumbering(t *testing.T) {
+	// Count the edges showing the Up color on the Up face in each case
+	edges := func(c *Cube) int {
+		count := 0
+		up, _ := c.Sticker(NewStickerIndex(Up, 1, 1))
+		for _, s := range []StickerIndex{NewStickerIndex(Up, 0, 1), NewStickerIndex(Up, 1, 0), NewStickerIndex(Up, 1, 2), NewStickerIndex(Up, 2, 1)} {
+			if color, _ := c.Sticker(s); color == up {
+				count++
+			}
+		}
+		return count
+	}
+	for i, algorithm := range OLLAlgorithms {
+		number := i + 1
+		got := edges(caseOf(t, algorithm.Algorithm))
+		switch {
+		case number <= 4 || number >= 17 && number <= 20:
+			if got != 0 {
+				t.Errorf("%s is a dot case but shows %d edges", algorithm.Name, got)
+			}
+		case number >= 21 && number <= 27:
+			if got != 4 {
+				t.Errorf("%s is a cross case but shows %d edges", algorithm.Name, got)
+			}
+		default:
+			if got != 2 {
+				t.Errorf("%s shows %d edges, want 2", algorithm.Name, got)
+			}
+		}
+	}
+}
+
+func TestRecognizeLastLayer_SolvesRandomLastLayers(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	library := append(append([]LastLayerAlgorithm{}, OLLAlgorithms...), PLLAlgorithms...)
+	for range 100 {
+		// Mix the last layer with algorithms of the library from random angles
+		cube := NewCube()
+		for range 3 {
+			turnUp(cube, random.Intn(4))
+			applyAlgorithm(t, cube, library[random.Intn(len(library))].Algorithm)
+		}
+
+		var steps []string
+		for range 3 {
+			found, err := cube.RecognizeLastLayer()
+			if err != nil {
+				t.Fatalf("RecognizeLastLayer failed after %v: %v", steps, err)
+			}
+			if found.Step == "solved" {
+				break
+			}
+			steps = append(steps, found.Case)
+			applyAlgorithm(t, cube, found.Solution)
+		}
+		if done, _ := stageDone(cube, "solved"); !done {
+			t.Fatalf("the cube is not solved after %s", strings.Join(steps, ", "))
+		}
+	}
+}
+
+func TestRecognizeLastLayer_Special(t *testing.T) {
+	if found, err := NewCube().RecognizeLastLayer(); err != nil || found.Step != "solved" {
+		t.Errorf("solved cube = %+v, %v; want solved", found, err)
+	}
+
+	cube := NewCube()
+	turnUp(cube, 1)
+	if found, err := cube.RecognizeLastLayer(); err != nil || found.Case != "skip" || found.Solution != "U'" {
+		t.Errorf("cube turned by U = %+v, %v; want a skip solved by U'", found, err)
+	}
+
+	applyAlgorithm(t, cube, "R")
+	if _, err := cube.RecognizeLastLayer(); !errors.Is(err, ErrF2LNotSolved) {
+		t.Errorf("expected ErrF2LNotSolved, got %v", err)
+	}
+}
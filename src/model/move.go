@@ -0,0 +1,52 @@
+package model
+
+import "fmt"
+
+// Move represents a single layer turn, expressed the same way as the rotate-axis API
+type Move struct {
+	Axis      string `json:"axis"`      // "x", "y", or "z"
+	Layer     int    `json:"layer"`     // 1 or -1
+	Direction int    `json:"direction"` // 1 for clockwise, -1 for counter-clockwise
+}
+
+// Validate checks that the move designates an existing layer and direction
+func (m Move) Validate() error {
+	if m.Axis != "x" && m.Axis != "y" && m.Axis != "z" {
+		return fmt.Errorf("invalid axis %q; must be 'x', 'y', or 'z'", m.Axis)
+	}
+	if m.Layer != 1 && m.Layer != -1 {
+		return fmt.Errorf("invalid layer %d; must be 1 or -1", m.Layer)
+	}
+	if m.Direction != 1 && m.Direction != -1 {
+		return fmt.Errorf("invalid direction %d; must be 1 or -1", m.Direction)
+	}
+	return nil
+}
+
+// ApplyMove validates the move and applies it to the cube
+func (c *Cube) ApplyMove(m Move) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	c.RotateAxis(GetCoordFromAxis(m.Axis, m.Layer), TurningDirection(m.Direction == 1))
+	return nil
+}
+
+// ApplyMoves validates every move before applying any of them,
+// so that the cube is left untouched when one of the moves is invalid
+func (c *Cube) ApplyMoves(moves []Move) error {
+	for i, m := range moves {
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("move %d: %w", i+1, err)
+		}
+	}
+	for _, m := range moves {
+		c.RotateAxis(GetCoordFromAxis(m.Axis, m.Layer), TurningDirection(m.Direction == 1))
+	}
+	return nil
+}
+
+// Inverse returns the move undoing this one
+func (m Move) Inverse() Move {
+	return Move{Axis: m.Axis, Layer: m.Layer, Direction: -m.Direction}
+}
@@ -0,0 +1,67 @@
+package model
+
+import "testing"
+
+func TestMove_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		move    Move
+		wantErr bool
+	}{
+		{name: "Valid front clockwise", move: Move{Axis: "x", Layer: 1, Direction: 1}},
+		{name: "Valid left counter-clockwise", move: Move{Axis: "z", Layer: -1, Direction: -1}},
+		{name: "Invalid axis", move: Move{Axis: "w", Layer: 1, Direction: 1}, wantErr: true},
+		{name: "Invalid layer", move: Move{Axis: "y", Layer: 0, Direction: 1}, wantErr: true},
+		{name: "Invalid direction", move: Move{Axis: "y", Layer: 1, Direction: 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.move.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCube_ApplyMove(t *testing.T) {
+	want := NewCube()
+	want.RotateAxis(BackAxis, CounterClockwise)
+
+	got := NewCube()
+	if err := got.ApplyMove(Move{Axis: "x", Layer: -1, Direction: -1}); err != nil {
+		t.Fatalf("ApplyMove failed: %v", err)
+	}
+
+	wantJSON, _ := want.ToReadableJSON()
+	gotJSON, _ := got.ToReadableJSON()
+	if gotJSON != wantJSON {
+		t.Errorf("ApplyMove() = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func TestCube_ApplyMovesIsAtomic(t *testing.T) {
+	cube := NewCube()
+	moves := []Move{
+		{Axis: "x", Layer: 1, Direction: 1},
+		{Axis: "q", Layer: 1, Direction: 1},
+	}
+	if err := cube.ApplyMoves(moves); err == nil {
+		t.Fatalf("ApplyMoves with an invalid move should fail")
+	}
+
+	got, _ := cube.ToReadableJSON()
+	if got != StartCubeString {
+		t.Errorf("ApplyMoves with an invalid move changed the cube: %s", got)
+	}
+}
+
+func TestMove_Inverse(t *testing.T) {
+	cube := NewCube()
+	move := Move{Axis: "z", Layer: -1, Direction: 1}
+	cube.ApplyMove(move)
+	cube.ApplyMove(move.Inverse())
+	if got, _ := cube.ToReadableJSON(); got != StartCubeString {
+		t.Errorf("A move followed by its inverse should leave the cube solved, got %s", got)
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+// NetPlacement places a face on the unfolded cube, in units of one face
+type NetPlacement struct {
+	Face FaceIndex
+	Row  int
+	Col  int
+}
+
+// NetLayout unfolds the cube into the usual cross, four faces wide and three high:
+// Up on top, then Left, Front, Right and Back, then Down below Front
+var NetLayout = [6]NetPlacement{
+	{Face: Up, Row: 0, Col: 1},
+	{Face: Left, Row: 1, Col: 0},
+	{Face: Front, Row: 1, Col: 1},
+	{Face: Right, Row: 1, Col: 2},
+	{Face: Back, Row: 1, Col: 3},
+	{Face: Down, Row: 2, Col: 1},
+}
+
+// facePosition returns the indices in Cube.Cubies of a sticker given by its row and column
+// on a face, read as when looking straight at that face with the usual net orientation:
+// Up seen with Back at the top, Down with Front at the top, the side faces with Up at the top.
+func facePosition(face FaceIndex, row, col int) CubeCoordinate {
+	switch face {
+	case Front:
+		return CubeCoordinate{X: 2, Y: 2 - row, Z: col}
+	case Back:
+		return CubeCoordinate{X: 0, Y: 2 - row, Z: 2 - col}
+	case Up:
+		return CubeCoordinate{X: row, Y: 2, Z: col}
+	case Down:
+		return CubeCoordinate{X: 2 - row, Y: 0, Z: col}
+	case Right:
+		return CubeCoordinate{X: 2 - col, Y: 2 - row, Z: 2}
+	case Left:
+		return CubeCoordinate{X: col, Y: 2 - row, Z: 0}
+	default:
+		return CubeCoordinate{}
+	}
+}
+
+// Face returns the colors of a face row by row, as seen when unfolding the cube into a net
+func (c *Cube) Face(face FaceIndex) [3][3]Color {
+	var colors [3][3]Color
+	for row := range 3 {
+		for col := range 3 {
+			colors[row][col], _ = c.stickerColor(facePosition(face, row, col), face)
+		}
+	}
+	return colors
+}
+
+// FaceNames returns the color names of every face in the given scheme, keyed by the lower case
+// name of the face ("front", "up"...), each read row by row as in Face
+func (c *Cube) FaceNames(scheme ColorScheme) map[string][3][3]string {
+	faces := make(map[string][3][3]string, 6)
+	for face := Front; face <= Down; face++ {
+		var names [3][3]string
+		for row, colors := range c.Face(face) {
+			for col, color := range colors {
+				names[row][col] = scheme.Color(color).Name
+			}
+		}
+		faces[faceName(face)] = names
+	}
+	return faces
+}
@@ -0,0 +1,72 @@
+package model
+
+import "testing"
+
+func TestFace_Solved(t *testing.T) {
+	cube := NewCube()
+	for face := Front; face <= Down; face++ {
+		want := cube.Cubies[centerPositions[face].X][centerPositions[face].Y][centerPositions[face].Z].Colors[face]
+		for _, row := range cube.Face(face) {
+			for _, color := range row {
+				if color != want {
+					t.Errorf("%s face shows %s, want only %s", faceName(face), colorToName(color), colorToName(want))
+				}
+			}
+		}
+	}
+}
+
+func TestFace_AdjacentEdgesMatchAfterTurn(t *testing.T) {
+	// After F, the right column of Left has moved to the bottom row of Up
+	cube := NewCube()
+	cube.ApplyMove(Move{Axis: "x", Layer: 1, Direction: 1})
+
+	up := cube.Face(Up)
+	left := NewCube().Face(Left)
+	for col := range 3 {
+		if up[2][col] != left[1][1] {
+			t.Errorf("Up face row 3, column %d = %s, want %s", col+1, colorToName(up[2][col]), colorToName(left[1][1]))
+		}
+	}
+
+	// The front face itself keeps its color
+	for _, row := range cube.Face(Front) {
+		for _, color := range row {
+			if color != White {
+				t.Errorf("Front face shows %s after F, want white", colorToName(color))
+			}
+		}
+	}
+}
+
+func TestFace_ReadingOrder(t *testing.T) {
+	// Mark stickers sharing a corner piece and check they meet at the net's corners
+	cube := NewCube()
+	cube.Cubies[2][2][2].Colors[Up] = Red     // URF corner, Up sticker
+	cube.Cubies[2][2][2].Colors[Right] = Red  // URF corner, Right sticker
+	cube.Cubies[2][2][2].Colors[Front] = Red  // URF corner, Front sticker
+	cube.Cubies[0][0][0].Colors[Back] = Green // DBL corner, Back sticker
+
+	if cube.Face(Up)[2][2] != Red {
+		t.Error("URF corner should be at the bottom right of Up")
+	}
+	if cube.Face(Front)[0][2] != Red {
+		t.Error("URF corner should be at the top right of Front")
+	}
+	if cube.Face(Right)[0][0] != Red {
+		t.Error("URF corner should be at the top left of Right")
+	}
+	if cube.Face(Back)[2][2] != Green {
+		t.Error("DBL corner should be at the bottom right of Back")
+	}
+}
+
+func TestNetLayout_CoversEveryFace(t *testing.T) {
+	seen := make(map[FaceIndex]bool)
+	for _, placement := range NetLayout {
+		seen[placement.Face] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("NetLayout places %d distinct faces, want 6", len(seen))
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// faceNotation maps the standard face letters to the layer they turn.
+// A clockwise turn is clockwise when looking at that face.
+var faceNotation = map[byte]Move{
+	'F': {Axis: "x", Layer: 1},
+	'B': {Axis: "x", Layer: -1},
+	'U': {Axis: "y", Layer: 1},
+	'D': {Axis: "y", Layer: -1},
+	'R': {Axis: "z", Layer: 1},
+	'L': {Axis: "z", Layer: -1},
+}
+
+// ParseAlgorithm converts a sequence in standard face notation (e.g. "R U R' U2")
+// into quarter-turn moves. Half turns are expanded to two clockwise moves.
+// Spaces between moves are optional.
+func ParseAlgorithm(algorithm string) ([]Move, error) {
+	moves := []Move{}
+	// Accept the typographic apostrophe often pasted from web pages
+	algorithm = strings.ReplaceAll(algorithm, "’", "'")
+
+	for i := 0; i < len(algorithm); {
+		letter := algorithm[i]
+		if letter == ' ' || letter == '\t' || letter == '\n' || letter == '\r' || letter == ',' {
+			i++
+			continue
+		}
+
+		move, ok := faceNotation[letter]
+		if !ok {
+			return nil, fmt.Errorf("invalid move %q at position %d; must be one of U, D, L, R, F, B", string(letter), i+1)
+		}
+		i++
+
+		// Read the optional modifiers in either order: 2 for a half turn, ' for counter-clockwise,
+		// so that both R2' and R'2 are read
+		count := 1
+		move.Direction = 1
+		for i < len(algorithm) {
+			if algorithm[i] == '2' && count == 1 {
+				count = 2
+			} else if algorithm[i] == '\'' && move.Direction == 1 {
+				move.Direction = -1
+			} else {
+				break
+			}
+			i++
+		}
+
+		for range count {
+			moves = append(moves, move)
+		}
+	}
+	return moves, nil
+}
+
+// String returns the move in standard face notation
+func (m Move) String() string {
+	for letter, face := range faceNotation {
+		if face.Axis == m.Axis && face.Layer == m.Layer {
+			if m.Direction == -1 {
+				return string(letter) + "'"
+			}
+			return string(letter)
+		}
+	}
+	return fmt.Sprintf("(%s,%d,%d)", m.Axis, m.Layer, m.Direction)
+}
+
+// FormatAlgorithm returns the moves in standard face notation, separated by spaces
+func FormatAlgorithm(moves []Move) string {
+	tokens := make([]string, len(moves))
+	for i, m := range moves {
+		tokens[i] = m.String()
+	}
+	return strings.Join(tokens, " ")
+}
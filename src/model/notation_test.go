@@ -0,0 +1,108 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		want      []Move
+		wantErr   bool
+	}{
+		{
+			name:      "Single clockwise turn",
+			algorithm: "F",
+			want:      []Move{{Axis: "x", Layer: 1, Direction: 1}},
+		},
+		{
+			name:      "Counter-clockwise and half turns",
+			algorithm: "B' U2",
+			want: []Move{
+				{Axis: "x", Layer: -1, Direction: -1},
+				{Axis: "y", Layer: 1, Direction: 1},
+				{Axis: "y", Layer: 1, Direction: 1},
+			},
+		},
+		{
+			name:      "Without spaces",
+			algorithm: "RL'D",
+			want: []Move{
+				{Axis: "z", Layer: 1, Direction: 1},
+				{Axis: "z", Layer: -1, Direction: -1},
+				{Axis: "y", Layer: -1, Direction: 1},
+			},
+		},
+		{
+			name:      "Half turn modifiers in either order",
+			algorithm: "R'2 R2'",
+			want: []Move{
+				{Axis: "z", Layer: 1, Direction: -1},
+				{Axis: "z", Layer: 1, Direction: -1},
+				{Axis: "z", Layer: 1, Direction: -1},
+				{Axis: "z", Layer: 1, Direction: -1},
+			},
+		},
+		{
+			name:      "Repeated modifier",
+			algorithm: "R22",
+			wantErr:   true,
+		},
+		{
+			name:      "Typographic apostrophe",
+			algorithm: "R’",
+			want:      []Move{{Axis: "z", Layer: 1, Direction: -1}},
+		},
+		{
+			name:      "Empty algorithm",
+			algorithm: "  ",
+			want:      []Move{},
+		},
+		{
+			name:      "Unsupported move",
+			algorithm: "R M",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAlgorithm(tt.algorithm)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAlgorithm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAlgorithm_RoundTrip(t *testing.T) {
+	algorithm := "R U R' U' F' B D L'"
+	moves, err := ParseAlgorithm(algorithm)
+	if err != nil {
+		t.Fatalf("ParseAlgorithm failed: %v", err)
+	}
+	if got := FormatAlgorithm(moves); got != algorithm {
+		t.Errorf("FormatAlgorithm() = %q, want %q", got, algorithm)
+	}
+}
+
+func TestParseAlgorithm_SexyMoveOrder(t *testing.T) {
+	// Six repetitions of R U R' U' return the cube to its initial state
+	moves, err := ParseAlgorithm("R U R' U' R U R' U' R U R' U' R U R' U' R U R' U' R U R' U'")
+	if err != nil {
+		t.Fatalf("ParseAlgorithm failed: %v", err)
+	}
+	cube := NewCube()
+	if err := cube.ApplyMoves(moves); err != nil {
+		t.Fatalf("ApplyMoves failed: %v", err)
+	}
+	got, _ := cube.ToReadableJSON()
+	if got != StartCubeString {
+		t.Errorf("(R U R' U')6 did not return to the initial state: %s", got)
+	}
+}
@@ -0,0 +1,409 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PieceSlot is the place of a corner or an edge on the cube.
+// Faces lists its outer faces in reference order: the U or D face first when the slot has one
+// (the F or B face otherwise), then clockwise around the piece for corners.
+type PieceSlot struct {
+	Name     string
+	Position CubeCoordinate // indices in Cube.Cubies
+	Faces    []FaceIndex
+}
+
+// CornerSlots lists the eight corner slots
+var CornerSlots = [8]PieceSlot{
+	{Name: "URF", Position: CubeCoordinate{2, 2, 2}, Faces: []FaceIndex{Up, Right, Front}},
+	{Name: "UFL", Position: CubeCoordinate{2, 2, 0}, Faces: []FaceIndex{Up, Front, Left}},
+	{Name: "ULB", Position: CubeCoordinate{0, 2, 0}, Faces: []FaceIndex{Up, Left, Back}},
+	{Name: "UBR", Position: CubeCoordinate{0, 2, 2}, Faces: []FaceIndex{Up, Back, Right}},
+	{Name: "DFR", Position: CubeCoordinate{2, 0, 2}, Faces: []FaceIndex{Down, Front, Right}},
+	{Name: "DLF", Position: CubeCoordinate{2, 0, 0}, Faces: []FaceIndex{Down, Left, Front}},
+	{Name: "DBL", Position: CubeCoordinate{0, 0, 0}, Faces: []FaceIndex{Down, Back, Left}},
+	{Name: "DRB", Position: CubeCoordinate{0, 0, 2}, Faces: []FaceIndex{Down, Right, Back}},
+}
+
+// EdgeSlots lists the twelve edge slots
+var EdgeSlots = [12]PieceSlot{
+	{Name: "UR", Position: CubeCoordinate{1, 2, 2}, Faces: []FaceIndex{Up, Right}},
+	{Name: "UF", Position: CubeCoordinate{2, 2, 1}, Faces: []FaceIndex{Up, Front}},
+	{Name: "UL", Position: CubeCoordinate{1, 2, 0}, Faces: []FaceIndex{Up, Left}},
+	{Name: "UB", Position: CubeCoordinate{0, 2, 1}, Faces: []FaceIndex{Up, Back}},
+	{Name: "DR", Position: CubeCoordinate{1, 0, 2}, Faces: []FaceIndex{Down, Right}},
+	{Name: "DF", Position: CubeCoordinate{2, 0, 1}, Faces: []FaceIndex{Down, Front}},
+	{Name: "DL", Position: CubeCoordinate{1, 0, 0}, Faces: []FaceIndex{Down, Left}},
+	{Name: "DB", Position: CubeCoordinate{0, 0, 1}, Faces: []FaceIndex{Down, Back}},
+	{Name: "FR", Position: CubeCoordinate{2, 1, 2}, Faces: []FaceIndex{Front, Right}},
+	{Name: "FL", Position: CubeCoordinate{2, 1, 0}, Faces: []FaceIndex{Front, Left}},
+	{Name: "BL", Position: CubeCoordinate{0, 1, 0}, Faces: []FaceIndex{Back, Left}},
+	{Name: "BR", Position: CubeCoordinate{0, 1, 2}, Faces: []FaceIndex{Back, Right}},
+}
+
+// centerPositions gives the indices of the center cubie of each face
+var centerPositions = map[FaceIndex]CubeCoordinate{
+	Front: {2, 1, 1},
+	Back:  {0, 1, 1},
+	Up:    {1, 2, 1},
+	Down:  {1, 0, 1},
+	Left:  {1, 1, 0},
+	Right: {1, 1, 2},
+}
+
+// PieceState describes the cube by the place and orientation of its corners and edges.
+// Pieces are numbered by their home slot in CornerSlots and EdgeSlots.
+type PieceState struct {
+	CornerPermutation [8]int  // piece found in each corner slot
+	CornerOrientation [8]int  // clockwise twist (0 to 2) of the piece in each corner slot
+	EdgePermutation   [12]int // piece found in each edge slot
+	EdgeOrientation   [12]int // flip (0 or 1) of the piece in each edge slot
+}
+
+// stickerColor returns the color shown on a face of the cubie at the given indices
+func (c *Cube) stickerColor(pos CubeCoordinate, face FaceIndex) (Color, bool) {
+	cubie := c.Cubies[pos.X][pos.Y][pos.Z]
+	if cubie == nil {
+		return 0, false
+	}
+	color, ok := cubie.Colors[face]
+	return color, ok
+}
+
+// centerFaces maps each center color to its face. Centers never move,
+// so they define which face every piece belongs to.
+func (c *Cube) centerFaces() (map[Color]FaceIndex, error) {
+	faces := make(map[Color]FaceIndex, 6)
+	for face := Front; face <= Down; face++ {
+		color, ok := c.stickerColor(centerPositions[face], face)
+		if !ok {
+			return nil, fmt.Errorf("missing center sticker on the %s face", faceName(face))
+		}
+		if other, found := faces[color]; found {
+			return nil, fmt.Errorf("the %s and %s centers have the same color", faceName(other), faceName(face))
+		}
+		faces[color] = face
+	}
+	return faces, nil
+}
+
+// slotColors returns the colors on the faces of a slot, in the slot's reference order
+func (c *Cube) slotColors(slot PieceSlot) ([]Color, error) {
+	colors := make([]Color, len(slot.Faces))
+	for i, face := range slot.Faces {
+		color, ok := c.stickerColor(slot.Position, face)
+		if !ok {
+			return nil, fmt.Errorf("missing %s sticker of the %s piece", faceName(face), slot.Name)
+		}
+		colors[i] = color
+	}
+	return colors, nil
+}
+
+// identifyPiece finds which piece shows the given colors and how it is oriented:
+// the orientation is the index of the face showing the piece's reference color
+func identifyPiece(colors []Color, slots []PieceSlot, centers map[Color]FaceIndex) (piece int, orientation int, ok bool) {
+	faces := make([]FaceIndex, len(colors))
+	for i, color := range colors {
+		face, found := centers[color]
+		if !found {
+			return 0, 0, false
+		}
+		faces[i] = face
+	}
+
+	for piece, home := range slots {
+		for orientation := range home.Faces {
+			// Colors follow the same cyclic order on the piece whatever its twist
+			match := true
+			for i := range faces {
+				if faces[(orientation+i)%len(faces)] != home.Faces[i] {
+					match = false
+					break
+				}
+			}
+			if match {
+				return piece, orientation, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// PieceState reads the place and orientation of every corner and edge from the stickers.
+// It fails when a piece does not exist or appears twice.
+func (c *Cube) PieceState() (PieceState, error) {
+	var state PieceState
+	centers, err := c.centerFaces()
+	if err != nil {
+		return state, err
+	}
+
+	read := func(slots []PieceSlot, permutation, orientation []int) error {
+		seen := make(map[int]string, len(slots))
+		for i, slot := range slots {
+			colors, err := c.slotColors(slot)
+			if err != nil {
+				return err
+			}
+			piece, twist, ok := identifyPiece(colors, slots, centers)
+			if !ok {
+				return fmt.Errorf("the %s piece shows %s, which is not a piece of the cube", slot.Name, colorList(colors))
+			}
+			if other, found := seen[piece]; found {
+				return fmt.Errorf("the %s piece appears twice, in %s and %s", slots[piece].Name, other, slot.Name)
+			}
+			seen[piece] = slot.Name
+			permutation[i] = piece
+			orientation[i] = twist
+		}
+		return nil
+	}
+
+	if err := read(CornerSlots[:], state.CornerPermutation[:], state.CornerOrientation[:]); err != nil {
+		return state, err
+	}
+	if err := read(EdgeSlots[:], state.EdgePermutation[:], state.EdgeOrientation[:]); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// PieceHome returns the position in the solved cube of the piece at the given indices, which identifies
+// the piece as it moves. It is read from the colors of the piece as in PieceState, so it holds for any
+// cube, turned or built from stickers. Both positions are indices in Cube.Cubies.
+func (c *Cube) PieceHome(pos CubeCoordinate) (CubeCoordinate, bool) {
+	if c.Cubies[pos.X][pos.Y][pos.Z] == nil {
+		return CubeCoordinate{}, false
+	}
+	slots := CornerSlots[:]
+	switch len(outerFaces(pos)) {
+	case 2:
+		slots = EdgeSlots[:]
+	case 0, 1:
+		// Centers and the core never move
+		return pos, true
+	}
+
+	centers, err := c.centerFaces()
+	if err != nil {
+		return CubeCoordinate{}, false
+	}
+	for _, slot := range slots {
+		if slot.Position != pos {
+			continue
+		}
+		colors, err := c.slotColors(slot)
+		if err != nil {
+			return CubeCoordinate{}, false
+		}
+		piece, _, ok := identifyPiece(colors, slots, centers)
+		return slots[piece].Position, ok
+	}
+	return CubeCoordinate{}, false
+}
+
+// FindPiece returns the current position of the piece whose solved position is home
+func (c *Cube) FindPiece(home CubeCoordinate) (CubeCoordinate, bool) {
+	for x := range 3 {
+		for y := range 3 {
+			for z := range 3 {
+				pos := CubeCoordinate{X: x, Y: y, Z: z}
+				if found, ok := c.PieceHome(pos); ok && found == home {
+					return pos, true
+				}
+			}
+		}
+	}
+	return CubeCoordinate{}, false
+}
+
+// PieceLocation tells where a corner or an edge is and how it is turned
+type PieceLocation struct {
+	Piece       string `json:"piece"`       // colors of the piece, e.g. "white-orange-blue"
+	Kind        string `json:"kind"`        // corner or edge
+	Home        string `json:"home"`        // slot of the piece in the solved cube, e.g. "URF"
+	Slot        string `json:"slot"`        // slot the piece is in now
+	Orientation int    `json:"orientation"` // clockwise twist (0 to 2) of a corner, flip (0 or 1) of an edge
+	Solved      bool   `json:"solved"`      // in its home slot with no twist or flip
+}
+
+// Pieces locates every corner, then every edge, in the order of their home slots.
+// Centers are left out since they never move.
+func (c *Cube) Pieces() ([]PieceLocation, error) {
+	state, err := c.PieceState()
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]PieceLocation, 0, len(CornerSlots)+len(EdgeSlots))
+	locate := func(kind string, slots []PieceSlot, permutation, orientation []int) {
+		found := make([]PieceLocation, len(slots))
+		for i, piece := range permutation {
+			found[piece] = PieceLocation{
+				Piece:       PieceName(slots[piece].Position),
+				Kind:        kind,
+				Home:        slots[piece].Name,
+				Slot:        slots[i].Name,
+				Orientation: orientation[i],
+				Solved:      piece == i && orientation[i] == 0,
+			}
+		}
+		locations = append(locations, found...)
+	}
+	locate("corner", CornerSlots[:], state.CornerPermutation[:], state.CornerOrientation[:])
+	locate("edge", EdgeSlots[:], state.EdgePermutation[:], state.EdgeOrientation[:])
+	return locations, nil
+}
+
+// LocatePiece tells where the piece with the given colors is, named as for ParsePiece
+func (c *Cube) LocatePiece(name string) (PieceLocation, error) {
+	home, err := ParsePiece(name)
+	if err != nil {
+		return PieceLocation{}, err
+	}
+	locations, err := c.Pieces()
+	if err != nil {
+		return PieceLocation{}, err
+	}
+	piece := PieceName(home)
+	for _, location := range locations {
+		if location.Piece == piece {
+			return location, nil
+		}
+	}
+	return PieceLocation{}, fmt.Errorf("the %s piece is a center, which never moves", piece)
+}
+
+// outerFaces lists the faces of a position that are on the outside of the cube
+func outerFaces(pos CubeCoordinate) []FaceIndex {
+	var faces []FaceIndex
+	for face := Front; face <= Down; face++ {
+		if IsOuterFace(pos, face) {
+			faces = append(faces, face)
+		}
+	}
+	return faces
+}
+
+// IsOuterFace reports whether the face of the cubie at the given position is on the outside of the cube
+func IsOuterFace(pos CubeCoordinate, face FaceIndex) bool {
+	switch face {
+	case Front:
+		return pos.X == 2
+	case Back:
+		return pos.X == 0
+	case Up:
+		return pos.Y == 2
+	case Down:
+		return pos.Y == 0
+	case Right:
+		return pos.Z == 2
+	case Left:
+		return pos.Z == 0
+	default:
+		return false
+	}
+}
+
+// PieceName returns the colors of the piece whose solved position is home, e.g. "white-blue-red"
+func PieceName(home CubeCoordinate) string {
+	solved := NewCube()
+	var colors []Color
+	for _, face := range outerFaces(home) {
+		color, _ := solved.stickerColor(home, face)
+		colors = append(colors, color)
+	}
+	return colorList(colors)
+}
+
+// ParsePiece returns the solved position of the piece with the given colors, named in any
+// order and separated by dashes or spaces, e.g. "white-blue-red" or "red blue white"
+func ParsePiece(name string) (CubeCoordinate, error) {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == ' ' || r == ','
+	})
+	want := make(map[Color]bool, len(fields))
+	for _, field := range fields {
+		color, err := ParseColor(field)
+		if err != nil {
+			return CubeCoordinate{}, err
+		}
+		want[color] = true
+	}
+
+	solved := NewCube()
+	for x := range 3 {
+		for y := range 3 {
+			for z := range 3 {
+				pos := CubeCoordinate{X: x, Y: y, Z: z}
+				faces := outerFaces(pos)
+				if len(faces) == 0 || len(faces) != len(fields) {
+					continue
+				}
+				match := true
+				for _, face := range faces {
+					color, _ := solved.stickerColor(pos, face)
+					match = match && want[color]
+				}
+				if match {
+					return pos, nil
+				}
+			}
+		}
+	}
+	return CubeCoordinate{}, fmt.Errorf("no piece shows the colors %q", name)
+}
+
+// ParseColor returns the color with the given name, e.g. "white"
+func ParseColor(name string) (Color, error) {
+	for color := White; color <= Green; color++ {
+		if colorToName(color) == strings.ToLower(name) {
+			return color, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown color %q", name)
+}
+
+// permutationParity returns 0 for an even permutation and 1 for an odd one
+func permutationParity(permutation []int) int {
+	parity := 0
+	for i := range permutation {
+		for j := i + 1; j < len(permutation); j++ {
+			if permutation[i] > permutation[j] {
+				parity ^= 1
+			}
+		}
+	}
+	return parity
+}
+
+// faceName returns the lower case name of a face
+func faceName(face FaceIndex) string {
+	switch face {
+	case Front:
+		return "front"
+	case Right:
+		return "right"
+	case Back:
+		return "back"
+	case Left:
+		return "left"
+	case Up:
+		return "up"
+	case Down:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// colorList returns color names joined with dashes, e.g. "white-blue-red"
+func colorList(colors []Color) string {
+	names := make([]string, len(colors))
+	for i, color := range colors {
+		names[i] = colorToName(color)
+	}
+	return strings.Join(names, "-")
+}
@@ -0,0 +1,223 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPieceState_Solved(t *testing.T) {
+	state, err := NewCube().PieceState()
+	if err != nil {
+		t.Fatalf("PieceState failed: %v", err)
+	}
+	for i := range state.CornerPermutation {
+		if state.CornerPermutation[i] != i || state.CornerOrientation[i] != 0 {
+			t.Errorf("Corner slot %s holds piece %d with twist %d, want piece %d untwisted",
+				CornerSlots[i].Name, state.CornerPermutation[i], state.CornerOrientation[i], i)
+		}
+	}
+	for i := range state.EdgePermutation {
+		if state.EdgePermutation[i] != i || state.EdgeOrientation[i] != 0 {
+			t.Errorf("Edge slot %s holds piece %d with flip %d, want piece %d unflipped",
+				EdgeSlots[i].Name, state.EdgePermutation[i], state.EdgeOrientation[i], i)
+		}
+	}
+}
+
+func TestPieceState_FaceTurns(t *testing.T) {
+	// A quarter turn of U cycles four corners and four edges without twisting or flipping them
+	cube := NewCube()
+	cube.ApplyMove(Move{Axis: "y", Layer: 1, Direction: 1})
+	state, err := cube.PieceState()
+	if err != nil {
+		t.Fatalf("PieceState failed: %v", err)
+	}
+	wantCorners := [8]int{3, 0, 1, 2, 4, 5, 6, 7} // UBR→URF, URF→UFL, UFL→ULB, ULB→UBR
+	if state.CornerPermutation != wantCorners {
+		t.Errorf("Corner permutation after U = %v, want %v", state.CornerPermutation, wantCorners)
+	}
+	if state.CornerOrientation != [8]int{} || state.EdgeOrientation != [12]int{} {
+		t.Errorf("U should not twist or flip pieces, got %v and %v", state.CornerOrientation, state.EdgeOrientation)
+	}
+
+	// A quarter turn of R twists the four corners it moves
+	cube = NewCube()
+	cube.ApplyMove(Move{Axis: "z", Layer: 1, Direction: 1})
+	state, err = cube.PieceState()
+	if err != nil {
+		t.Fatalf("PieceState failed: %v", err)
+	}
+	wantTwists := [8]int{2, 0, 0, 1, 1, 0, 0, 2}
+	if state.CornerOrientation != wantTwists {
+		t.Errorf("Corner orientation after R = %v, want %v", state.CornerOrientation, wantTwists)
+	}
+
+	// A quarter turn of F flips the four edges it moves
+	cube = NewCube()
+	cube.ApplyMove(Move{Axis: "x", Layer: 1, Direction: 1})
+	state, err = cube.PieceState()
+	if err != nil {
+		t.Fatalf("PieceState failed: %v", err)
+	}
+	flipped := 0
+	for _, flip := range state.EdgeOrientation {
+		flipped += flip
+	}
+	if flipped != 4 {
+		t.Errorf("F flipped %d edges, want 4", flipped)
+	}
+}
+
+func TestPieceState_MissingPiece(t *testing.T) {
+	cube := NewCube()
+	// Paint the URF corner with two white stickers
+	cube.Cubies[2][2][2].Colors[Right] = White
+	if _, err := cube.PieceState(); err == nil {
+		t.Error("PieceState should fail for a corner that does not exist")
+	}
+}
+
+func TestPermutationParity(t *testing.T) {
+	tests := []struct {
+		permutation []int
+		want        int
+	}{
+		{[]int{0, 1, 2, 3}, 0},
+		{[]int{1, 0, 2, 3}, 1},
+		{[]int{1, 2, 0, 3}, 0},
+		{[]int{3, 0, 1, 2}, 1},
+	}
+	for _, tt := range tests {
+		if got := permutationParity(tt.permutation); got != tt.want {
+			t.Errorf("permutationParity(%v) = %d, want %d", tt.permutation, got, tt.want)
+		}
+	}
+}
+
+func TestFindPiece_FollowsTurns(t *testing.T) {
+	cube := NewCube()
+	urf := CubeCoordinate{X: 2, Y: 2, Z: 2}
+	if pos, ok := cube.FindPiece(urf); !ok || pos != urf {
+		t.Fatalf("FindPiece(URF) on a solved cube = %v, %v; want %v", pos, ok, urf)
+	}
+
+	// R takes the URF corner to UBR
+	cube.ApplyMove(Move{Axis: "z", Layer: 1, Direction: 1})
+	want := CubeCoordinate{X: 0, Y: 2, Z: 2}
+	if pos, ok := cube.FindPiece(urf); !ok || pos != want {
+		t.Errorf("FindPiece(URF) after R = %v, %v; want %v", pos, ok, want)
+	}
+}
+
+func TestPieceHome_ReadFromColors(t *testing.T) {
+	cube := NewCube()
+	cube.Scramble(30)
+
+	// A cube read back from its state only knows its colors
+	data, err := json.Marshal(cube.Cubies)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	loaded := &Cube{}
+	if err := json.Unmarshal(data, &loaded.Cubies); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	// Every piece is found where it is, and the homes of the pieces are all different
+	homes := make(map[CubeCoordinate]bool)
+	for x := range 3 {
+		for y := range 3 {
+			for z := range 3 {
+				pos := CubeCoordinate{X: x, Y: y, Z: z}
+				home, ok := loaded.PieceHome(pos)
+				if !ok {
+					t.Fatalf("no home for the piece at %v", pos)
+				}
+				if found, ok := loaded.FindPiece(home); !ok || found != pos {
+					t.Errorf("FindPiece(%v) = %v, %v; want %v", home, found, ok, pos)
+				}
+				homes[home] = true
+			}
+		}
+	}
+	if len(homes) != 27 {
+		t.Errorf("got %d different homes, want 27", len(homes))
+	}
+}
+func TestParsePiece(t *testing.T) {
+	// The solved URF corner shows the Up, Right and Front colors
+	name := PieceName(CubeCoordinate{X: 2, Y: 2, Z: 2})
+	if name != "white-orange-blue" {
+		t.Errorf("PieceName(URF) = %q, want %q", name, "white-orange-blue")
+	}
+
+	for _, input := range []string{"white-orange-blue", "Blue White Orange", "orange,blue,white"} {
+		pos, err := ParsePiece(input)
+		if err != nil || pos != (CubeCoordinate{X: 2, Y: 2, Z: 2}) {
+			t.Errorf("ParsePiece(%q) = %v, %v; want (2,2,2)", input, pos, err)
+		}
+	}
+
+	if pos, err := ParsePiece("white"); err != nil || pos != centerPositions[Front] {
+		t.Errorf("ParsePiece(white) = %v, %v; want the front center", pos, err)
+	}
+	if _, err := ParsePiece("white-yellow"); err == nil {
+		t.Error("ParsePiece should reject colors of opposite faces")
+	}
+	if _, err := ParsePiece("white-purple"); err == nil {
+		t.Error("ParsePiece should reject unknown colors")
+	}
+}
+
+func TestPieces_Solved(t *testing.T) {
+	locations, err := NewCube().Pieces()
+	if err != nil {
+		t.Fatalf("Pieces failed: %v", err)
+	}
+	if len(locations) != 20 {
+		t.Fatalf("got %d pieces, want 20", len(locations))
+	}
+	for _, location := range locations {
+		if !location.Solved || location.Slot != location.Home {
+			t.Errorf("%s piece is in %s with orientation %d, want solved in %s", location.Piece, location.Slot, location.Orientation, location.Home)
+		}
+	}
+}
+
+func TestLocatePiece_AfterTurns(t *testing.T) {
+	cube := NewCube()
+	moves, _ := ParseAlgorithm("R F")
+	cube.ApplyMoves(moves)
+
+	tests := []struct {
+		name        string
+		home        string
+		slot        string
+		orientation int
+	}{
+		// R takes URF to UBR with its Up sticker on Back, and F leaves it there
+		{"blue white orange", "URF", "UBR", 1},
+		// F takes UF to FR with its Up sticker on Right
+		{"white-blue", "UF", "FR", 1},
+		{"red-green", "DL", "DL", 0},
+	}
+	for _, tt := range tests {
+		location, err := cube.LocatePiece(tt.name)
+		if err != nil {
+			t.Fatalf("LocatePiece(%q) failed: %v", tt.name, err)
+		}
+		if location.Home != tt.home || location.Slot != tt.slot || location.Orientation != tt.orientation {
+			t.Errorf("LocatePiece(%q) = %+v, want home %s, slot %s, orientation %d", tt.name, location, tt.home, tt.slot, tt.orientation)
+		}
+		if location.Solved != (tt.home == tt.slot && tt.orientation == 0) {
+			t.Errorf("LocatePiece(%q).Solved = %v", tt.name, location.Solved)
+		}
+	}
+
+	if _, err := cube.LocatePiece("white"); err == nil {
+		t.Error("expected an error for a center")
+	}
+	if _, err := cube.LocatePiece("white-yellow"); err == nil {
+		t.Error("expected an error for a piece that does not exist")
+	}
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SchemeColor is how a Color is named and drawn
+type SchemeColor struct {
+	Name   string `json:"name"`   // name shown by the renderings and the faces view, e.g. "white"
+	Hex    string `json:"hex"`    // #RRGGBB used by the renderings
+	Letter string `json:"letter"` // single letter drawn on the stickers when the scheme asks for letters
+}
+
+// ColorScheme gives the appearance of the six colors.
+// The Color constants identify the face a sticker belongs to in the solved cube
+// (White is the front face's color); a scheme only changes how they look.
+type ColorScheme struct {
+	Name    string         `json:"name"`
+	Colors  [6]SchemeColor `json:"colors"`  // indexed by Color
+	Letters bool           `json:"letters"` // draw each sticker's letter over its color
+}
+
+// Predefined color schemes, by name
+var ColorSchemes = map[string]ColorScheme{
+	"western": {
+		Name: "western",
+		Colors: [6]SchemeColor{
+			White:  {"white", "#FFFFFF", "W"},
+			Orange: {"orange", "#FFA500", "O"},
+			Yellow: {"yellow", "#FFFF00", "Y"},
+			Red:    {"red", "#FF0000", "R"},
+			Blue:   {"blue", "#0000FF", "B"},
+			Green:  {"green", "#00FF00", "G"},
+		},
+	},
+	// White opposite blue and yellow opposite green
+	"japanese": {
+		Name: "japanese",
+		Colors: [6]SchemeColor{
+			White:  {"white", "#FFFFFF", "W"},
+			Orange: {"orange", "#FFA500", "O"},
+			Yellow: {"blue", "#0000FF", "B"},
+			Red:    {"red", "#FF0000", "R"},
+			Blue:   {"yellow", "#FFFF00", "Y"},
+			Green:  {"green", "#00FF00", "G"},
+		},
+	},
+	"high-contrast": {
+		Name: "high-contrast",
+		Colors: [6]SchemeColor{
+			White:  {"white", "#FFFFFF", "W"},
+			Orange: {"orange", "#FF6D00", "O"},
+			Yellow: {"yellow", "#FFEA00", "Y"},
+			Red:    {"red", "#D50000", "R"},
+			Blue:   {"blue", "#2962FF", "B"},
+			Green:  {"green", "#00C853", "G"},
+		},
+		Letters: true,
+	},
+	// Okabe-Ito palette, distinguishable with the common forms of color blindness
+	"color-blind": {
+		Name: "color-blind",
+		Colors: [6]SchemeColor{
+			White:  {"white", "#FFFFFF", "W"},
+			Orange: {"orange", "#E69F00", "O"},
+			Yellow: {"yellow", "#F0E442", "Y"},
+			Red:    {"red", "#D55E00", "R"},
+			Blue:   {"blue", "#0072B2", "B"},
+			Green:  {"green", "#009E73", "G"},
+		},
+		Letters: true,
+	},
+}
+
+// DefaultColorScheme is the scheme in use until another one is set
+const DefaultColorScheme = "western"
+
+var (
+	currentScheme = ColorSchemes[DefaultColorScheme]
+	schemeLock    sync.RWMutex
+
+	hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// CurrentColorScheme returns the scheme in use
+func CurrentColorScheme() ColorScheme {
+	schemeLock.RLock()
+	defer schemeLock.RUnlock()
+	return currentScheme
+}
+
+// SetColorScheme validates a scheme and makes it the one in use
+func SetColorScheme(scheme ColorScheme) error {
+	if err := scheme.Validate(); err != nil {
+		return err
+	}
+	for i := range scheme.Colors {
+		scheme.Colors[i].Name = strings.ToLower(scheme.Colors[i].Name)
+		scheme.Colors[i].Hex = strings.ToUpper(scheme.Colors[i].Hex)
+	}
+
+	schemeLock.Lock()
+	defer schemeLock.Unlock()
+	currentScheme = scheme
+	return nil
+}
+
+// LookupColorScheme returns the predefined scheme with the given name, in any case
+func LookupColorScheme(name string) (ColorScheme, error) {
+	scheme, ok := ColorSchemes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return ColorScheme{}, fmt.Errorf("unknown color scheme %q, expected one of %s", name, strings.Join(ColorSchemeNames(), ", "))
+	}
+	return scheme, nil
+}
+
+// UseColorScheme makes the predefined scheme with the given name the one in use
+func UseColorScheme(name string) error {
+	scheme, err := LookupColorScheme(name)
+	if err != nil {
+		return err
+	}
+	return SetColorScheme(scheme)
+}
+
+// ColorSchemeNames lists the predefined schemes in alphabetical order
+func ColorSchemeNames() []string {
+	names := make([]string, 0, len(ColorSchemes))
+	for name := range ColorSchemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Validate checks that every color has a distinct name, a #RRGGBB value and a single letter
+func (s ColorScheme) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("the color scheme has no name")
+	}
+	names := make(map[string]bool, 6)
+	for color, c := range s.Colors {
+		name := strings.ToLower(c.Name)
+		if name == "" || strings.ContainsAny(name, " ,-_") {
+			return fmt.Errorf("color %d needs a name without spaces, commas, dashes or underscores, got %q", color, c.Name)
+		}
+		if names[name] {
+			return fmt.Errorf("color name %q is used twice", c.Name)
+		}
+		names[name] = true
+		if !hexColorPattern.MatchString(c.Hex) {
+			return fmt.Errorf("color %q needs a #RRGGBB value, got %q", c.Name, c.Hex)
+		}
+		if len([]rune(c.Letter)) != 1 {
+			return fmt.Errorf("color %q needs a single letter, got %q", c.Name, c.Letter)
+		}
+	}
+	return nil
+}
+
+// Color returns how a color looks in the scheme
+func (s ColorScheme) Color(color Color) SchemeColor {
+	if color < White || color > Green {
+		return SchemeColor{Name: "unknown", Hex: "#808080", Letter: "?"}
+	}
+	return s.Colors[color]
+}
+
+// RGB returns the color's value as 0xRRGGBB
+func (c SchemeColor) RGB() uint32 {
+	var rgb uint32
+	fmt.Sscanf(strings.TrimPrefix(c.Hex, "#"), "%06x", &rgb)
+	return rgb
+}
+
+// LetterHex returns a #RRGGBB value for the letter that stays readable over the color
+func (c SchemeColor) LetterHex() string {
+	rgb := c.RGB()
+	r, g, b := float64(rgb>>16&0xFF), float64(rgb>>8&0xFF), float64(rgb&0xFF)
+	if 0.299*r+0.587*g+0.114*b > 140 {
+		return "#111111"
+	}
+	return "#FFFFFF"
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColorSchemes_Valid(t *testing.T) {
+	for name, scheme := range ColorSchemes {
+		if scheme.Name != name {
+			t.Errorf("scheme %q is named %q", name, scheme.Name)
+		}
+		if err := scheme.Validate(); err != nil {
+			t.Errorf("scheme %q: %v", name, err)
+		}
+	}
+}
+
+func TestUseColorScheme_KeepsColorNames(t *testing.T) {
+	defer UseColorScheme(DefaultColorScheme)
+
+	if err := UseColorScheme("japanese"); err != nil {
+		t.Fatalf("UseColorScheme failed: %v", err)
+	}
+
+	// The Japanese scheme shows the back face in blue, but pieces and colors keep their names
+	json, err := NewCube().ToReadableJSON()
+	if err != nil {
+		t.Fatalf("ToReadableJSON failed: %v", err)
+	}
+	if !strings.Contains(json, `"back": "yellow"`) {
+		t.Errorf("expected the back face to be yellow, got %s", json)
+	}
+	if name := PieceName(CubeCoordinate{X: 0, Y: 2, Z: 2}); name != "orange-yellow-blue" {
+		t.Errorf("PieceName = %q, want orange-yellow-blue", name)
+	}
+	color, err := ParseColor("yellow")
+	if err != nil || color != Yellow {
+		t.Errorf("ParseColor(yellow) = %v, %v, want the back color", color, err)
+	}
+	// Up, on top of the text net, is yellow in the Japanese scheme
+	if text := NewCube().Render(TextLetters); !strings.HasPrefix(strings.TrimSpace(text), "Y Y Y") {
+		t.Errorf("the text net should show the scheme's letters:\n%s", text)
+	}
+
+	if err := UseColorScheme("nope"); err == nil {
+		t.Error("expected an error for an unknown scheme")
+	}
+}
+
+func TestLookupColorScheme(t *testing.T) {
+	scheme, err := LookupColorScheme(" High-Contrast ")
+	if err != nil || scheme.Name != "high-contrast" {
+		t.Errorf("LookupColorScheme = %q, %v, want high-contrast", scheme.Name, err)
+	}
+	if _, err := LookupColorScheme("nope"); err == nil {
+		t.Error("expected an error for an unknown scheme")
+	}
+}
+
+func TestSetColorScheme_Custom(t *testing.T) {
+	defer UseColorScheme(DefaultColorScheme)
+
+	scheme := ColorSchemes[DefaultColorScheme]
+	scheme.Name = "custom"
+	scheme.Colors[Blue] = SchemeColor{Name: "Purple", Hex: "#80a0ff", Letter: "P"}
+	if err := SetColorScheme(scheme); err != nil {
+		t.Fatalf("SetColorScheme failed: %v", err)
+	}
+
+	got := CurrentColorScheme().Color(Blue)
+	if got.Name != "purple" || got.Hex != "#80A0FF" {
+		t.Errorf("Color(Blue) = %+v, want purple #80A0FF", got)
+	}
+	if rgb := got.RGB(); rgb != 0x80A0FF {
+		t.Errorf("RGB = %06X, want 80A0FF", rgb)
+	}
+}
+
+func TestColorScheme_ValidateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*ColorScheme)
+	}{
+		{"duplicate name", func(s *ColorScheme) { s.Colors[Red].Name = "Orange" }},
+		{"bad hex", func(s *ColorScheme) { s.Colors[Red].Hex = "red" }},
+		{"dash in name", func(s *ColorScheme) { s.Colors[Red].Name = "dark-red" }},
+		{"long letter", func(s *ColorScheme) { s.Colors[Red].Letter = "RD" }},
+		{"no scheme name", func(s *ColorScheme) { s.Name = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := ColorSchemes[DefaultColorScheme]
+			tt.change(&scheme)
+			if err := scheme.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+			if err := SetColorScheme(scheme); err == nil {
+				t.Error("expected SetColorScheme to refuse the scheme")
+			}
+			if CurrentColorScheme().Name != DefaultColorScheme {
+				t.Errorf("scheme changed to %q", CurrentColorScheme().Name)
+			}
+		})
+	}
+}
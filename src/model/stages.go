@@ -0,0 +1,190 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stage is a step of a layer-by-layer solve, checked on a part of the cube
+type Stage struct {
+	Name        string
+	Description string
+	// Stickers that must show the color of the center of their face; nil for stages checked otherwise
+	Stickers []StickerIndex
+	// check counts the parts of the cube done for stages that are not a mask of stickers
+	check func(c *Cube) (missing []string, total int, err error)
+}
+
+// StageStatus tells how far a cube is in a stage
+type StageStatus struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Done        bool     `json:"done"`
+	Matching    int      `json:"matching"`          // stickers, or pieces for piece stages, already right
+	Total       int      `json:"total"`             // stickers or pieces checked by the stage
+	Missing     []string `json:"missing,omitempty"` // stickers (e.g. "F8") or slots (e.g. "UR") still wrong
+}
+
+// Stages lists the checks of a beginner solve with the cross on Down, in solving order
+var Stages = []Stage{
+	{
+		Name:        "cross",
+		Description: "cross on the Down face, its edges matching the side centers",
+		Stickers:    stickerMask(crossStickers),
+	},
+	{
+		Name:        "first-layer",
+		Description: "Down face and the bottom row of the side faces",
+		Stickers:    stickerMask(firstLayerStickers),
+	},
+	{
+		Name:        "f2l",
+		Description: "first two layers: Down face and the two bottom rows of the side faces",
+		Stickers:    stickerMask(f2lStickers),
+	},
+	{
+		Name:        "eo",
+		Description: "every edge oriented, so that it can be solved with U, D, R, L and half turns of F and B",
+		check:       misorientedEdges,
+	},
+	{
+		Name:        "oll",
+		Description: "first two layers and the whole Up face",
+		Stickers:    stickerMask(f2lStickers, faceStickers(Up)),
+	},
+	{
+		Name:        "solved",
+		Description: "every sticker",
+		Stickers:    stickerMask(allStickers),
+	},
+}
+
+// crossStickers selects the center and edges of Down, and the stickers above them on the side faces
+func crossStickers(s StickerIndex) bool {
+	row, col := s.Row(), s.Col()
+	switch s.Face() {
+	case Down:
+		return row == 1 || col == 1
+	case Up:
+		return false
+	default:
+		return row == 2 && col == 1 || row == 1 && col == 1
+	}
+}
+
+// firstLayerStickers selects Down and the bottom row of the side faces, with their centers
+func firstLayerStickers(s StickerIndex) bool {
+	switch s.Face() {
+	case Down:
+		return true
+	case Up:
+		return false
+	default:
+		return s.Row() == 2 || s.Row() == 1 && s.Col() == 1
+	}
+}
+
+// f2lStickers selects Down and the two bottom rows of the side faces
+func f2lStickers(s StickerIndex) bool {
+	switch s.Face() {
+	case Down:
+		return true
+	case Up:
+		return false
+	default:
+		return s.Row() >= 1
+	}
+}
+
+func allStickers(StickerIndex) bool { return true }
+
+// faceStickers selects the stickers of a face
+func faceStickers(face FaceIndex) func(StickerIndex) bool {
+	return func(s StickerIndex) bool { return s.Face() == face }
+}
+
+// stickerMask lists the stickers selected by any of the given predicates
+func stickerMask(selects ...func(StickerIndex) bool) []StickerIndex {
+	var mask []StickerIndex
+	for s := StickerIndex(0); s < StickerCount; s++ {
+		for _, selected := range selects {
+			if selected(s) {
+				mask = append(mask, s)
+				break
+			}
+		}
+	}
+	return mask
+}
+
+// misorientedEdges lists the edge slots holding a flipped edge: an edge is oriented when its
+// Up or Down color, or else its Front or Back color, is on the first face of its slot
+func misorientedEdges(c *Cube) ([]string, int, error) {
+	state, err := c.PieceState()
+	if err != nil {
+		return nil, 0, err
+	}
+	var missing []string
+	for i, flip := range state.EdgeOrientation {
+		if flip != 0 {
+			missing = append(missing, EdgeSlots[i].Name)
+		}
+	}
+	return missing, len(EdgeSlots), nil
+}
+
+// FindStage returns the stage with the given name
+func FindStage(name string) (Stage, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := make([]string, len(Stages))
+	for i, stage := range Stages {
+		if stage.Name == name {
+			return stage, nil
+		}
+		names[i] = stage.Name
+	}
+	return Stage{}, fmt.Errorf("unknown stage %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Check tells how far the cube is in the stage
+func (s Stage) Check(c *Cube) (StageStatus, error) {
+	status := StageStatus{Name: s.Name, Description: s.Description}
+	if s.check != nil {
+		missing, total, err := s.check(c)
+		if err != nil {
+			return status, err
+		}
+		status.Missing, status.Total = missing, total
+	} else {
+		centers := make(map[FaceIndex]Color, 6)
+		for face, pos := range centerPositions {
+			color, ok := c.stickerColor(pos, face)
+			if !ok {
+				return status, fmt.Errorf("missing center sticker on the %s face", faceName(face))
+			}
+			centers[face] = color
+		}
+		for _, sticker := range s.Stickers {
+			if color, err := c.Sticker(sticker); err != nil || color != centers[sticker.Face()] {
+				status.Missing = append(status.Missing, sticker.String())
+			}
+		}
+		status.Total = len(s.Stickers)
+	}
+	status.Matching = status.Total - len(status.Missing)
+	status.Done = len(status.Missing) == 0
+	return status, nil
+}
+
+// CheckStages tells how far the cube is in every stage, in solving order
+func (c *Cube) CheckStages() ([]StageStatus, error) {
+	statuses := make([]StageStatus, 0, len(Stages))
+	for _, stage := range Stages {
+		status, err := stage.Check(c)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
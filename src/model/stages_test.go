@@ -0,0 +1,85 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStages_MaskSizes(t *testing.T) {
+	want := map[string]int{"cross": 13, "first-layer": 25, "f2l": 33, "oll": 42, "solved": 54}
+	for _, stage := range Stages {
+		if size, ok := want[stage.Name]; ok && len(stage.Stickers) != size {
+			t.Errorf("%s checks %d stickers, want %d", stage.Name, len(stage.Stickers), size)
+		}
+	}
+}
+
+func TestCheckStages_Solved(t *testing.T) {
+	statuses, err := NewCube().CheckStages()
+	if err != nil {
+		t.Fatalf("CheckStages failed: %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Done || status.Matching != status.Total || len(status.Missing) != 0 {
+			t.Errorf("%s stage on the solved cube = %+v, want done", status.Name, status)
+		}
+	}
+}
+
+func TestCheckStages_AfterTurns(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		done      map[string]bool
+	}{
+		// U only moves the last layer
+		{"U", map[string]bool{"cross": true, "first-layer": true, "f2l": true, "eo": true, "oll": true, "solved": false}},
+		// R breaks everything but the edge orientation
+		{"R", map[string]bool{"cross": false, "first-layer": false, "f2l": false, "eo": true, "oll": false, "solved": false}},
+		// F flips four edges
+		{"F", map[string]bool{"cross": false, "eo": false}},
+	}
+	for _, tt := range tests {
+		cube := NewCube()
+		moves, _ := ParseAlgorithm(tt.algorithm)
+		cube.ApplyMoves(moves)
+		for name, done := range tt.done {
+			stage, err := FindStage(name)
+			if err != nil {
+				t.Fatalf("FindStage(%q) failed: %v", name, err)
+			}
+			status, err := stage.Check(cube)
+			if err != nil {
+				t.Fatalf("Check(%s) failed: %v", name, err)
+			}
+			if status.Done != done {
+				t.Errorf("after %s, %s done = %v, want %v (missing %v)", tt.algorithm, name, status.Done, done, status.Missing)
+			}
+		}
+	}
+}
+
+func TestStageCheck_Missing(t *testing.T) {
+	cube := NewCube()
+	moves, _ := ParseAlgorithm("F")
+	cube.ApplyMoves(moves)
+
+	eo, _ := FindStage("eo")
+	status, err := eo.Check(cube)
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if want := []string{"UF", "DF", "FR", "FL"}; !reflect.DeepEqual(status.Missing, want) || status.Matching != 8 {
+		t.Errorf("eo after F misses %v with %d matching, want %v with 8", status.Missing, status.Matching, want)
+	}
+
+	// The Front face turns in place, and the Right color comes down to the DF edge
+	cross, _ := FindStage("cross")
+	status, _ = cross.Check(cube)
+	if want := []string{"D2"}; !reflect.DeepEqual(status.Missing, want) {
+		t.Errorf("cross after F misses %v, want %v", status.Missing, want)
+	}
+
+	if _, err := FindStage("pll"); err == nil {
+		t.Error("expected an error for an unknown stage")
+	}
+}
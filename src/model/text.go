@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TextStyle is the way stickers are written in a text net
+type TextStyle string
+
+const (
+	// TextLetters writes each sticker as the letter of its color, e.g. W for white
+	TextLetters TextStyle = "letters"
+	// TextANSI paints each sticker as a block of its color with 24-bit ANSI escape codes
+	TextANSI TextStyle = "ansi"
+)
+
+// ParseTextStyle returns the text style with the given name, letters when the name is empty
+func ParseTextStyle(name string) (TextStyle, error) {
+	switch strings.ToLower(name) {
+	case "", string(TextLetters):
+		return TextLetters, nil
+	case string(TextANSI):
+		return TextANSI, nil
+	default:
+		return "", fmt.Errorf("unknown text style %q, expected letters or ansi", name)
+	}
+}
+
+// Render writes the cube as a text net in the current color scheme:
+//
+//	      B B B
+//	      B B B
+//	      B B B
+//	R R R W W W O O O Y Y Y
+//	R R R W W W O O O Y Y Y
+//	R R R W W W O O O Y Y Y
+//	      G G G
+//	      G G G
+//	      G G G
+//
+// Up is on top, then Left, Front, Right and Back, then Down below Front, as in NetLayout.
+func (c *Cube) Render(style TextStyle) string {
+	return c.RenderScheme(style, CurrentColorScheme())
+}
+
+// RenderScheme writes the cube as a text net in the given color scheme
+func (c *Cube) RenderScheme(style TextStyle, scheme ColorScheme) string {
+	// Stickers of the net, 12 wide and 9 high, nil where there is no face
+	var grid [9][12]*Color
+	for _, placement := range NetLayout {
+		colors := c.Face(placement.Face)
+		for row := range 3 {
+			for col := range 3 {
+				grid[placement.Row*3+row][placement.Col*3+col] = &colors[row][col]
+			}
+		}
+	}
+
+	var b strings.Builder
+	for _, line := range grid {
+		var text strings.Builder
+		for col, color := range line {
+			switch {
+			case color == nil:
+				// As wide as a sticker in either style
+				text.WriteString("  ")
+			case style == TextANSI:
+				rgb := scheme.Color(*color).RGB()
+				fmt.Fprintf(&text, "\x1b[48;2;%d;%d;%dm  \x1b[0m", rgb>>16&0xFF, rgb>>8&0xFF, rgb&0xFF)
+			default:
+				text.WriteString(scheme.Color(*color).Letter)
+				if col < len(line)-1 {
+					text.WriteByte(' ')
+				}
+			}
+		}
+		b.WriteString(strings.TrimRight(text.String(), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
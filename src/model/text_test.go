@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender_Solved(t *testing.T) {
+	want := `      B B B
+      B B B
+      B B B
+R R R W W W O O O Y Y Y
+R R R W W W O O O Y Y Y
+R R R W W W O O O Y Y Y
+      G G G
+      G G G
+      G G G
+`
+	if got := NewCube().RenderScheme(TextLetters, ColorSchemes[DefaultColorScheme]); got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRender_AfterTurn(t *testing.T) {
+	c := NewCube()
+	moves, _ := ParseAlgorithm("R")
+	c.ApplyMoves(moves)
+
+	lines := strings.Split(c.RenderScheme(TextLetters, ColorSchemes[DefaultColorScheme]), "\n")
+	// R brings the Front column up, and the Down column to the Front
+	if lines[0] != "      B B W" {
+		t.Errorf("Up row = %q, want \"      B B W\"", lines[0])
+	}
+	if lines[3] != "R R R W W G O O O B Y Y" {
+		t.Errorf("middle row = %q, want \"R R R W W G O O O B Y Y\"", lines[3])
+	}
+}
+
+func TestRender_ANSI(t *testing.T) {
+	out := NewCube().RenderScheme(TextANSI, ColorSchemes[DefaultColorScheme])
+	if n := strings.Count(out, "\x1b[48;2;"); n != 54 {
+		t.Errorf("got %d colored stickers, want 54", n)
+	}
+	if !strings.Contains(out, "\x1b[48;2;255;255;255m  \x1b[0m") {
+		t.Error("expected white stickers")
+	}
+}
+
+func TestParseTextStyle(t *testing.T) {
+	for name, want := range map[string]TextStyle{"": TextLetters, "letters": TextLetters, "ANSI": TextANSI} {
+		if got, err := ParseTextStyle(name); err != nil || got != want {
+			t.Errorf("ParseTextStyle(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseTextStyle("braille"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
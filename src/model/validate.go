@@ -0,0 +1,127 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError lists the problems preventing the stickers from forming a solvable cube
+type ValidationError struct {
+	Problems []string `json:"problems"`
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// SetSticker paints the sticker found on a face of the cubie at the given indices
+func (c *Cube) SetSticker(pos CubeCoordinate, face FaceIndex, color Color) error {
+	if pos.X < 0 || pos.X > 2 || pos.Y < 0 || pos.Y > 2 || pos.Z < 0 || pos.Z > 2 {
+		return fmt.Errorf("invalid cubie position %v", pos)
+	}
+	if color < White || color > Green {
+		return fmt.Errorf("invalid color %d", color)
+	}
+
+	// Only faces on the outside of the cube carry a sticker
+	if !IsOuterFace(pos, face) {
+		return fmt.Errorf("the cubie at %v has no sticker on its %s face", pos, faceName(face))
+	}
+
+	cubie := c.Cubies[pos.X][pos.Y][pos.Z]
+	if cubie == nil {
+		cubie = NewCubie()
+		c.Cubies[pos.X][pos.Y][pos.Z] = cubie
+	}
+	cubie.Colors[face] = color
+	return nil
+}
+
+// Validate checks that the stickers describe a cube that can be solved by turning its faces.
+// It returns nil or a *ValidationError listing every problem found.
+func (c *Cube) Validate() error {
+	var problems []string
+
+	// Each color must cover exactly nine stickers
+	counts := make(map[Color]int, 6)
+	for face := Front; face <= Down; face++ {
+		for row := range 3 {
+			for col := range 3 {
+				color, ok := c.stickerColor(facePosition(face, row, col), face)
+				if !ok {
+					problems = append(problems, fmt.Sprintf("missing sticker on the %s face at row %d, column %d", faceName(face), row+1, col+1))
+					continue
+				}
+				counts[color]++
+			}
+		}
+	}
+	for color := White; color <= Green; color++ {
+		if counts[color] != 9 {
+			problems = append(problems, fmt.Sprintf("%s appears on %d stickers instead of 9", colorToName(color), counts[color]))
+		}
+	}
+
+	// Every piece must exist once, which also requires distinct centers
+	state, err := c.PieceState()
+	if err != nil {
+		problems = append(problems, err.Error())
+		return &ValidationError{Problems: problems}
+	}
+
+	// Turning preserves the total twist of the corners, the total flip of the edges,
+	// and swaps corners and edges together
+	twist := 0
+	for _, orientation := range state.CornerOrientation {
+		twist += orientation
+	}
+	if twist%3 != 0 {
+		problems = append(problems, "a corner is twisted")
+	}
+
+	flip := 0
+	for _, orientation := range state.EdgeOrientation {
+		flip += orientation
+	}
+	if flip%2 != 0 {
+		problems = append(problems, "an edge is flipped")
+	}
+
+	if permutationParity(state.CornerPermutation[:]) != permutationParity(state.EdgePermutation[:]) {
+		problems = append(problems, "two pieces are swapped (permutation parity)")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// CubeFromState builds a cube from a state given by a client once it has been validated,
+// filling in the hidden core if it is left out
+func CubeFromState(state [3][3][3]*Cubie) (*Cube, error) {
+	cube := &Cube{Cubies: state}
+	if err := cube.Validate(); err != nil {
+		return nil, err
+	}
+	if cube.Cubies[1][1][1] == nil {
+		cube.Cubies[1][1][1] = NewCubie()
+	}
+	return cube, nil
+}
+
+// LoadCube replaces the shared cube with the given one once it has been validated
+func LoadCube(cube *Cube) error {
+	if err := cube.Validate(); err != nil {
+		return err
+	}
+
+	// The hidden core has no sticker and may be left out
+	loaded := cube.Clone()
+	if loaded.Cubies[1][1][1] == nil {
+		loaded.Cubies[1][1][1] = NewCubie()
+	}
+	SharedCube = loaded
+	RestartHistory()
+	return nil
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate_Solved(t *testing.T) {
+	if err := NewCube().Validate(); err != nil {
+		t.Errorf("Solved cube should be valid, got %v", err)
+	}
+}
+
+func TestValidate_Scrambled(t *testing.T) {
+	for range 20 {
+		cube := NewCube()
+		cube.Scramble(30)
+		if err := cube.Validate(); err != nil {
+			t.Fatalf("Scrambled cube should be valid, got %v", err)
+		}
+	}
+}
+
+func TestValidate_Unsolvable(t *testing.T) {
+	tests := []struct {
+		name  string
+		paint func(c *Cube)
+	}{
+		{"twisted corner", func(c *Cube) {
+			// Turn the URF corner a third of a turn in place
+			cu := c.Cubies[2][2][2]
+			cu.Colors[Up], cu.Colors[Right], cu.Colors[Front] = cu.Colors[Front], cu.Colors[Up], cu.Colors[Right]
+		}},
+		{"flipped edge", func(c *Cube) {
+			cu := c.Cubies[2][2][1]
+			cu.Colors[Up], cu.Colors[Front] = cu.Colors[Front], cu.Colors[Up]
+		}},
+		{"swapped edges", func(c *Cube) {
+			// Exchange the UF and UR edges
+			uf, ur := c.Cubies[2][2][1], c.Cubies[1][2][2]
+			uf.Colors[Up], ur.Colors[Up] = ur.Colors[Up], uf.Colors[Up]
+			uf.Colors[Front], ur.Colors[Right] = ur.Colors[Right], uf.Colors[Front]
+		}},
+		{"wrong color count", func(c *Cube) {
+			c.Cubies[2][1][1].Colors[Front] = Red
+		}},
+		{"missing sticker", func(c *Cube) {
+			delete(c.Cubies[0][0][0].Colors, Down)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cube := NewCube()
+			cube.Scramble(10)
+			tt.paint(cube)
+			err := cube.Validate()
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			if len(validationErr.Problems) == 0 {
+				t.Error("ValidationError should list problems")
+			}
+		})
+	}
+}
+
+func TestSetSticker(t *testing.T) {
+	cube := NewCube()
+	if err := cube.SetSticker(CubeCoordinate{X: 2, Y: 1, Z: 1}, Front, Red); err != nil {
+		t.Fatalf("SetSticker failed: %v", err)
+	}
+	if cube.Cubies[2][1][1].Colors[Front] != Red {
+		t.Errorf("Front center = %s, want red", colorToName(cube.Cubies[2][1][1].Colors[Front]))
+	}
+
+	if err := cube.SetSticker(CubeCoordinate{X: 1, Y: 1, Z: 1}, Front, Red); err == nil {
+		t.Error("SetSticker should reject an inner face")
+	}
+	if err := cube.SetSticker(CubeCoordinate{X: 3, Y: 1, Z: 1}, Front, Red); err == nil {
+		t.Error("SetSticker should reject an out of range position")
+	}
+	if err := cube.SetSticker(CubeCoordinate{X: 2, Y: 1, Z: 1}, Front, Color(42)); err == nil {
+		t.Error("SetSticker should reject an unknown color")
+	}
+}
+
+func TestFacePosition_CoversFace(t *testing.T) {
+	for face := Front; face <= Down; face++ {
+		seen := make(map[CubeCoordinate]bool)
+		for row := range 3 {
+			for col := range 3 {
+				seen[facePosition(face, row, col)] = true
+			}
+		}
+		if len(seen) != 9 {
+			t.Errorf("%s face covers %d positions, want 9", faceName(face), len(seen))
+		}
+		if !seen[centerPositions[face]] || facePosition(face, 1, 1) != centerPositions[face] {
+			t.Errorf("%s face center is not at row 2, column 2", faceName(face))
+		}
+	}
+}
+
+func TestLoadCube(t *testing.T) {
+	ResetCube()
+	invalid := NewCube()
+	invalid.Cubies[2][2][1].Colors[Up], invalid.Cubies[2][2][1].Colors[Front] = White, Blue
+	if err := LoadCube(invalid); err == nil {
+		t.Fatal("LoadCube should reject a flipped edge")
+	}
+	if shared, _ := SharedCube.ToReadableJSON(); shared != StartCubeString {
+		t.Error("A rejected cube should not replace the shared cube")
+	}
+
+	cube := NewCube()
+	cube.ApplyMove(Move{Axis: "x", Layer: 1, Direction: 1})
+	want, _ := cube.ToReadableJSON()
+	cube.Cubies[1][1][1] = nil
+	if err := LoadCube(cube); err != nil {
+		t.Fatalf("LoadCube failed: %v", err)
+	}
+	if SharedCube.Cubies[1][1][1] == nil {
+		t.Error("LoadCube should fill in the hidden core")
+	}
+	if got, _ := SharedCube.ToReadableJSON(); got != want {
+		t.Errorf("Shared cube = %s, want %s", got, want)
+	}
+	ResetCube()
+}
@@ -0,0 +1,104 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"kikokai/src/model"
+	"strings"
+	"testing"
+)
+
+func TestGLTF_Structure(t *testing.T) {
+	scheme := model.ColorSchemes[model.DefaultColorScheme]
+	data, err := GLTF(model.NewCube(), scheme)
+	if err != nil {
+		t.Fatalf("GLTF failed: %v", err)
+	}
+
+	var doc gltfDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("GLTF is not valid JSON: %v", err)
+	}
+	if len(doc.Nodes) != 27 || len(doc.Nodes[0].Children) != 26 {
+		t.Errorf("got %d nodes with %d pieces, want 27 nodes with 26 pieces", len(doc.Nodes), len(doc.Nodes[0].Children))
+	}
+
+	buffer, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(doc.Buffers[0].URI, "data:application/octet-stream;base64,"))
+	if err != nil || len(buffer) != doc.Buffers[0].ByteLength {
+		t.Errorf("buffer of %d bytes, want %d (%v)", len(buffer), doc.Buffers[0].ByteLength, err)
+	}
+
+	// The front center shows white on its front side only
+	for i, node := range doc.Nodes {
+		if node.Name != "white" {
+			continue
+		}
+		for side, primitive := range doc.Meshes[*node.Mesh].Primitives {
+			want := "body"
+			if boxFaces[side].face == model.Front {
+				want = "white"
+			}
+			if got := doc.Materials[primitive.Material].Name; got != want {
+				t.Errorf("node %d, %s side: material %q, want %q", i, boxFaces[side].name, got, want)
+			}
+		}
+		return
+	}
+	t.Error("no node for the white center")
+}
+
+func TestOBJ_Structure(t *testing.T) {
+	scheme := model.ColorSchemes[model.DefaultColorScheme]
+	data, err := OBJ(model.NewCube(), scheme)
+	if err != nil {
+		t.Fatalf("OBJ failed: %v", err)
+	}
+
+	counts := map[string]int{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			counts[fields[0]]++
+		}
+	}
+	if counts["o"] != 26 || counts["f"] != 26*6 || counts["v"] != 26*24 || counts["vn"] != 6 {
+		t.Errorf("got %d objects, %d faces, %d vertices and %d normals", counts["o"], counts["f"], counts["v"], counts["vn"])
+	}
+
+	// 9 stickers of each color, all the other sides use the body material
+	if n := bytes.Count(data, []byte("usemtl white\n")); n != 9 {
+		t.Errorf("got %d white sides, want 9", n)
+	}
+	if n := bytes.Count(data, []byte("usemtl body\n")); n != 26*6-54 {
+		t.Errorf("got %d body sides, want %d", n, 26*6-54)
+	}
+
+	// The materials follow the scheme of the export rather than the one in use
+	if !bytes.Contains(data, []byte("mtllib state.mtl?scheme=western\n")) {
+		t.Errorf("the material library does not name the scheme")
+	}
+	custom := scheme
+	custom.Colors[model.White].Hex = "#EEEEEE"
+	if data, err := OBJ(model.NewCube(), custom); err != nil || !bytes.Contains(data, []byte("mtllib state.mtl\n")) {
+		t.Errorf("a custom scheme should use the materials of the scheme in use, err %v", err)
+	}
+
+	mtl, err := MTL(scheme)
+	if err != nil {
+		t.Fatalf("MTL failed: %v", err)
+	}
+	if n := bytes.Count(mtl, []byte("newmtl ")); n != 7 {
+		t.Errorf("got %d materials, want 7", n)
+	}
+}
+
+func TestBoxFaces_OutwardWinding(t *testing.T) {
+	for _, side := range boxFaces {
+		c := side.corners()
+		a := vec3{c[1][0] - c[0][0], c[1][1] - c[0][1], c[1][2] - c[0][2]}
+		b := vec3{c[2][0] - c[0][0], c[2][1] - c[0][1], c[2][2] - c[0][2]}
+		if a.cross(b).dot(vec3(side.normal)) <= 0 {
+			t.Errorf("the corners of the %s side do not run counter-clockwise around its normal", side.name)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"kikokai/src/model"
+	"slices"
+)
+
+const (
+	// MaxAnimationMoves bounds the length of an animated sequence
+	MaxAnimationMoves = 100
+	// MaxAnimationSize bounds the width of an animation, every frame being drawn at that size
+	MaxAnimationSize = 600
+	// MaxAnimationFrames bounds the frames drawn for the turns, moves times frames per turn
+	MaxAnimationFrames = 600
+
+	// DefaultTurnFrames is the number of frames showing each turn in the isometric view
+	DefaultTurnFrames = 6
+	// DefaultTurnDelay is the time spent on each turn, in hundredths of a second
+	DefaultTurnDelay = 40
+	// holdDelay is the time the first and last states are shown, in hundredths of a second
+	holdDelay = 100
+	// turnPadding is the room left around the isometric view for the turning layers, in stickers
+	turnPadding = 0.7
+)
+
+// AnimationOptions describe an animated picture of a sequence of moves
+type AnimationOptions struct {
+	Options
+	TurnFrames int // frames per turn; the net shows only the state after each turn
+	TurnDelay  int // time spent on each turn, in hundredths of a second
+}
+
+// DefaultAnimationOptions returns the options of an isometric animation in the current color scheme
+func DefaultAnimationOptions() AnimationOptions {
+	opts := DefaultOptions()
+	opts.View = ViewIsometric
+	opts.Size = 300
+	return AnimationOptions{
+		Options:    opts,
+		TurnFrames: DefaultTurnFrames,
+		TurnDelay:  DefaultTurnDelay,
+	}
+}
+
+// GIF animates the moves applied one after the other from the given cube, which is left untouched
+func GIF(start *model.Cube, moves []model.Move, opts AnimationOptions) ([]byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if len(moves) > MaxAnimationMoves {
+		return nil, fmt.Errorf("at most %d moves can be animated, got %d", MaxAnimationMoves, len(moves))
+	}
+	if opts.TurnFrames < 1 || opts.TurnFrames > 30 {
+		return nil, fmt.Errorf("frames per turn must be between 1 and 30, got %d", opts.TurnFrames)
+	}
+	if opts.Size > MaxAnimationSize {
+		return nil, fmt.Errorf("an animation is at most %d pixels wide, got %d", MaxAnimationSize, opts.Size)
+	}
+	if frames := len(moves) * opts.TurnFrames; frames > MaxAnimationFrames {
+		return nil, fmt.Errorf("an animation has at most %d frames of turns, got %d moves of %d frames", MaxAnimationFrames, len(moves), opts.TurnFrames)
+	}
+	if opts.TurnDelay < opts.TurnFrames {
+		return nil, fmt.Errorf("a turn must last at least one hundredth of a second per frame, got %d for %d frames", opts.TurnDelay, opts.TurnFrames)
+	}
+
+	for i, m := range moves {
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("move %d: %w", i+1, err)
+		}
+	}
+
+	c := start.Clone()
+
+	// A layer half way through a turn sticks out of the cube, leave room for it
+	pad := 0.0
+	if opts.View == ViewIsometric {
+		pad = turnPadding
+	}
+
+	palette := animationPalette(opts.Scheme)
+	anim := &gif.GIF{}
+	addFrame := func(s scene, delay int) {
+		anim.Image = append(anim.Image, paletted(s.padded(pad), opts.Size, palette))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	addFrame(layout(c, opts.View, opts.Scheme), holdDelay)
+	for i, m := range moves {
+		// The net cannot show a layer part way through a turn
+		if opts.View == ViewIsometric {
+			for frame := 1; frame < opts.TurnFrames; frame++ {
+				addFrame(turnLayout(c, opts.Scheme, m, float64(frame)/float64(opts.TurnFrames)), opts.TurnDelay/opts.TurnFrames)
+			}
+		}
+		c.ApplyMove(m)
+
+		delay := opts.TurnDelay / opts.TurnFrames
+		if opts.View == ViewNet {
+			delay = opts.TurnDelay
+		}
+		if i == len(moves)-1 {
+			delay = holdDelay
+		}
+		addFrame(layout(c, opts.View, opts.Scheme), delay)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, anim); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// paletted draws a scene over a white background with the colors of the palette
+func paletted(s scene, width int, palette color.Palette) *image.Paletted {
+	img := rasterize(s, width)
+	background := image.NewRGBA(img.Bounds())
+	draw.Draw(background, background.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(background, background.Bounds(), img, image.Point{}, draw.Over)
+
+	frame := image.NewPaletted(img.Bounds(), palette)
+	draw.Draw(frame, frame.Bounds(), background, image.Point{}, draw.Src)
+	return frame
+}
+
+// animationPalette holds the colors of the scheme, the body and the background,
+// with blends of each pair for the smoothed edges
+func animationPalette(scheme model.ColorScheme) color.Palette {
+	base := []color.RGBA{parseHex("#FFFFFF"), parseHex(bodyColor)}
+	for _, c := range scheme.Colors {
+		base = append(base, parseHex(c.Hex), parseHex(c.LetterHex()))
+	}
+	rgb := func(c color.RGBA) int { return int(c.R)<<16 | int(c.G)<<8 | int(c.B) }
+	slices.SortFunc(base, func(a, b color.RGBA) int { return rgb(a) - rgb(b) })
+	base = slices.Compact(base)
+
+	var palette color.Palette
+	for _, c := range base {
+		palette = append(palette, c)
+	}
+	for i, a := range base {
+		for _, b := range base[i+1:] {
+			for _, k := range []int{1, 2, 3} {
+				palette = append(palette, color.RGBA{
+					R: uint8((int(a.R)*(4-k) + int(b.R)*k) / 4),
+					G: uint8((int(a.G)*(4-k) + int(b.G)*k) / 4),
+					B: uint8((int(a.B)*(4-k) + int(b.B)*k) / 4),
+					A: 0xFF,
+				})
+			}
+		}
+	}
+	// Custom schemes with unusual letter colors could need more than a GIF allows
+	return palette[:min(len(palette), 256)]
+}
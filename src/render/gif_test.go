@@ -0,0 +1,116 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image/gif"
+	"kikokai/src/model"
+	"slices"
+	"testing"
+)
+
+// stickers lists the visible stickers of a scene as their center and color, in a stable order
+func stickers(s scene) []string {
+	var list []string
+	for _, p := range s.Polygons {
+		if p.Fill == bodyColor {
+			continue
+		}
+		center := p.center()
+		list = append(list, fmt.Sprintf("%.3f,%.3f %s", center.X, center.Y, p.Fill))
+	}
+	slices.Sort(list)
+	return list
+}
+
+func TestTurnLayout_EndsOnTheTurnedCube(t *testing.T) {
+	scheme := model.ColorSchemes[model.DefaultColorScheme]
+	for _, name := range []string{"R", "R'", "L", "U", "D'", "F", "B'"} {
+		t.Run(name, func(t *testing.T) {
+			moves, err := model.ParseAlgorithm(name)
+			if err != nil {
+				t.Fatalf("ParseAlgorithm failed: %v", err)
+			}
+			c := model.NewCube()
+			c.ApplyMoves([]model.Move{{Axis: "x", Layer: 1, Direction: 1}, {Axis: "z", Layer: -1, Direction: 1}})
+
+			// Not turned at all, the cube looks as in the isometric view
+			if got, want := stickers(turnLayout(c, scheme, moves[0], 0)), stickers(isometricLayout(c, scheme)); !slices.Equal(got, want) {
+				t.Errorf("before the turn:\n got %v\nwant %v", got, want)
+			}
+
+			// A whole quarter turn gives the picture of the turned cube
+			turned := turnLayout(c, scheme, moves[0], 1)
+			c.ApplyMove(moves[0])
+			if got, want := stickers(turned), stickers(isometricLayout(c, scheme)); !slices.Equal(got, want) {
+				t.Errorf("after the turn:\n got %v\nwant %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGIF_Frames(t *testing.T) {
+	moves, _ := model.ParseAlgorithm("R U R' U'")
+	start := model.NewCube()
+
+	tests := []struct {
+		view   View
+		frames int
+	}{
+		{ViewIsometric, 1 + 4*DefaultTurnFrames},
+		{ViewNet, 1 + 4},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.view), func(t *testing.T) {
+			opts := DefaultAnimationOptions()
+			opts.View = tt.view
+			opts.Size = 60
+			data, err := GIF(start, moves, opts)
+			if err != nil {
+				t.Fatalf("GIF failed: %v", err)
+			}
+			anim, err := gif.DecodeAll(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("GIF does not decode: %v", err)
+			}
+			if len(anim.Image) != tt.frames {
+				t.Errorf("got %d frames, want %d", len(anim.Image), tt.frames)
+			}
+		})
+	}
+
+	got, _ := start.ToReadableJSON()
+	want, _ := model.NewCube().ToReadableJSON()
+	if got != want {
+		t.Error("GIF changed the starting cube")
+	}
+}
+
+func TestGIF_InvalidMoves(t *testing.T) {
+	_, err := GIF(model.NewCube(), []model.Move{{Axis: "w", Layer: 1, Direction: 1}}, DefaultAnimationOptions())
+	if err == nil {
+		t.Error("expected an error for an invalid move")
+	}
+}
+
+func TestGIF_Limits(t *testing.T) {
+	moves, _ := model.ParseAlgorithm("R U R' U'")
+
+	opts := DefaultAnimationOptions()
+	opts.Size = MaxAnimationSize + 1
+	if _, err := GIF(model.NewCube(), moves, opts); err == nil {
+		t.Error("expected an error for an animation wider than MaxAnimationSize")
+	}
+
+	// Within the move limit, but too many frames in all
+	long := make([]model.Move, MaxAnimationMoves)
+	for i := range long {
+		long[i] = moves[i%len(moves)]
+	}
+	opts = DefaultAnimationOptions()
+	opts.TurnFrames = MaxAnimationFrames/MaxAnimationMoves + 1
+	opts.TurnDelay = 100
+	if _, err := GIF(model.NewCube(), long, opts); err == nil {
+		t.Error("expected an error for an animation over MaxAnimationFrames")
+	}
+}
@@ -0,0 +1,197 @@
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"kikokai/src/model"
+	"math"
+)
+
+// The parts of a glTF 2.0 document used by the export, see https://registry.khronos.org/glTF/specs/2.0/glTF-2.0.html
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes"`
+	Materials   []gltfMaterial   `json:"materials"`
+	Accessors   []gltfAccessor   `json:"accessors"`
+	BufferViews []gltfBufferView `json:"bufferViews"`
+	Buffers     []gltfBuffer     `json:"buffers"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type gltfNode struct {
+	Name        string    `json:"name"`
+	Mesh        *int      `json:"mesh,omitempty"`
+	Translation []float64 `json:"translation,omitempty"`
+	Children    []int     `json:"children,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    int            `json:"indices"`
+	Material   int            `json:"material"`
+}
+
+type gltfMaterial struct {
+	Name                 string  `json:"name"`
+	PBRMetallicRoughness gltfPBR `json:"pbrMetallicRoughness"`
+}
+
+type gltfPBR struct {
+	BaseColorFactor [4]float64 `json:"baseColorFactor"`
+	MetallicFactor  float64    `json:"metallicFactor"`
+	RoughnessFactor float64    `json:"roughnessFactor"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ByteOffset    int       `json:"byteOffset,omitempty"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min,omitempty"`
+	Max           []float64 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int `json:"buffer"`
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+	Target     int `json:"target,omitempty"`
+}
+
+type gltfBuffer struct {
+	ByteLength int    `json:"byteLength"`
+	URI        string `json:"uri"`
+}
+
+// glTF constants
+const (
+	gltfFloat         = 5126
+	gltfUnsignedShort = 5123
+	gltfArrayBuffer   = 34962
+	gltfElementBuffer = 34963
+)
+
+// GLTF exports the cube as a glTF 2.0 document with its buffer embedded. Every piece is a node
+// holding a box whose six sides are separate primitives, colored like the meshes of the WASM view.
+func GLTF(c *model.Cube, scheme model.ColorScheme) ([]byte, error) {
+	if err := scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	doc := gltfDocument{
+		Asset: gltfAsset{Version: "2.0", Generator: "kikokai"},
+	}
+
+	// Materials: the plastic body, then the six colors of the scheme
+	doc.Materials = append(doc.Materials, gltfMaterial{Name: "body", PBRMetallicRoughness: pbr(bodyColor)})
+	for _, color := range scheme.Colors {
+		doc.Materials = append(doc.Materials, gltfMaterial{Name: color.Name, PBRMetallicRoughness: pbr(color.Hex)})
+	}
+
+	// The pieces share one box: positions and normals of its 24 corners, then the indices of each side
+	var buf bytes.Buffer
+	var positions, normals []float32
+	for _, side := range boxFaces {
+		for _, corner := range side.corners() {
+			for axis := range 3 {
+				positions = append(positions, float32(corner[axis]))
+				normals = append(normals, float32(side.normal[axis]))
+			}
+		}
+	}
+	binary.Write(&buf, binary.LittleEndian, positions)
+	binary.Write(&buf, binary.LittleEndian, normals)
+	verticesLength := buf.Len()
+	for i := range boxFaces {
+		first := uint16(4 * i)
+		binary.Write(&buf, binary.LittleEndian, []uint16{first, first + 1, first + 2, first, first + 2, first + 3})
+	}
+
+	doc.BufferViews = []gltfBufferView{
+		{Buffer: 0, ByteOffset: 0, ByteLength: verticesLength, Target: gltfArrayBuffer},
+		{Buffer: 0, ByteOffset: verticesLength, ByteLength: buf.Len() - verticesLength, Target: gltfElementBuffer},
+	}
+	half := pieceSize / 2
+	doc.Accessors = []gltfAccessor{
+		{BufferView: 0, ComponentType: gltfFloat, Count: 24, Type: "VEC3", Min: []float64{-half, -half, -half}, Max: []float64{half, half, half}},
+		{BufferView: 0, ByteOffset: len(positions) * 4, ComponentType: gltfFloat, Count: 24, Type: "VEC3"},
+	}
+	for i := range boxFaces {
+		doc.Accessors = append(doc.Accessors, gltfAccessor{BufferView: 1, ByteOffset: i * 12, ComponentType: gltfUnsignedShort, Count: 6, Type: "SCALAR"})
+	}
+	doc.Buffers = []gltfBuffer{{
+		ByteLength: buf.Len(),
+		URI:        "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}}
+
+	// One node for the whole cube, holding a node per piece
+	doc.Nodes = []gltfNode{{Name: "cube"}}
+	for _, p := range pieces(c) {
+		mesh := gltfMesh{Name: p.name}
+		for i, color := range p.colors {
+			material := 0
+			if color != nil {
+				material = 1 + int(*color)
+			}
+			mesh.Primitives = append(mesh.Primitives, gltfPrimitive{
+				Attributes: map[string]int{"POSITION": 0, "NORMAL": 1},
+				Indices:    2 + i,
+				Material:   material,
+			})
+		}
+		meshIndex := len(doc.Meshes)
+		doc.Meshes = append(doc.Meshes, mesh)
+
+		doc.Nodes[0].Children = append(doc.Nodes[0].Children, len(doc.Nodes))
+		doc.Nodes = append(doc.Nodes, gltfNode{
+			Name:        p.name,
+			Mesh:        &meshIndex,
+			Translation: p.position[:],
+		})
+	}
+	doc.Scenes = []gltfScene{{Nodes: []int{0}}}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// pbr returns a plastic material of the given #RRGGBB color
+func pbr(hex string) gltfPBR {
+	rgb := model.SchemeColor{Hex: hex}.RGB()
+	return gltfPBR{
+		BaseColorFactor: [4]float64{
+			linear(rgb >> 16 & 0xFF),
+			linear(rgb >> 8 & 0xFF),
+			linear(rgb & 0xFF),
+			1,
+		},
+		RoughnessFactor: 0.5,
+	}
+}
+
+// linear converts an sRGB component to the linear value glTF expects for colors
+func linear(component uint32) float64 {
+	c := float64(component) / 255
+	if c <= 0.04045 {
+		return math.Round(c/12.92*1e4) / 1e4
+	}
+	return math.Round(math.Pow((c+0.055)/1.055, 2.4)*1e4) / 1e4
+}
@@ -0,0 +1,136 @@
+package render
+
+import (
+	"kikokai/src/model"
+	"math"
+)
+
+const (
+	bodyColor   = "#111111" // plastic showing between the stickers
+	stickerGap  = 0.06      // space left around each sticker, in stickers
+	sceneMargin = 0.25      // space left around the cube, in stickers
+)
+
+type point struct {
+	X, Y float64
+}
+
+// polygon is a filled shape of the picture, with an optional letter written in its middle
+type polygon struct {
+	Points     []point
+	Fill       string // #RRGGBB
+	Letter     string
+	LetterFill string
+}
+
+// scene is the picture of a cube as polygons, in units of one sticker, drawn in order
+type scene struct {
+	Width, Height float64
+	Polygons      []polygon
+}
+
+// center returns the average of the polygon's corners
+func (p polygon) center() point {
+	var c point
+	for _, pt := range p.Points {
+		c.X += pt.X
+		c.Y += pt.Y
+	}
+	n := float64(len(p.Points))
+	return point{c.X / n, c.Y / n}
+}
+
+// padded returns the scene with room added all around it
+func (s scene) padded(pad float64) scene {
+	if pad == 0 {
+		return s
+	}
+	out := scene{Width: s.Width + 2*pad, Height: s.Height + 2*pad}
+	for _, p := range s.Polygons {
+		moved := p
+		moved.Points = make([]point, len(p.Points))
+		for i, pt := range p.Points {
+			moved.Points[i] = point{pt.X + pad, pt.Y + pad}
+		}
+		out.Polygons = append(out.Polygons, moved)
+	}
+	return out
+}
+
+// layout draws the cube as seen in the given view
+func layout(c *model.Cube, view View, scheme model.ColorScheme) scene {
+	if view == ViewIsometric {
+		return isometricLayout(c, scheme)
+	}
+	return netLayout(c, scheme)
+}
+
+// faceMap places the corner at (u, v) of a face, u counted in stickers to the right
+// of the face's left edge and v down from its top edge, as seen in the net
+type faceMap func(u, v float64) point
+
+// drawFace adds the body of a face and its nine stickers to the scene
+func (s *scene) drawFace(colors [3][3]model.Color, scheme model.ColorScheme, at faceMap) {
+	s.Polygons = append(s.Polygons, polygon{
+		Points: []point{at(0, 0), at(3, 0), at(3, 3), at(0, 3)},
+		Fill:   bodyColor,
+	})
+
+	for row := range 3 {
+		for col := range 3 {
+			u0, v0 := float64(col)+stickerGap, float64(row)+stickerGap
+			u1, v1 := float64(col+1)-stickerGap, float64(row+1)-stickerGap
+			color := scheme.Color(colors[row][col])
+			sticker := polygon{
+				Points: []point{at(u0, v0), at(u1, v0), at(u1, v1), at(u0, v1)},
+				Fill:   color.Hex,
+			}
+			if scheme.Letters {
+				sticker.Letter = color.Letter
+				sticker.LetterFill = color.LetterHex()
+			}
+			s.Polygons = append(s.Polygons, sticker)
+		}
+	}
+}
+
+// netLayout unfolds the cube into a cross, 12 stickers wide and 9 high
+func netLayout(c *model.Cube, scheme model.ColorScheme) scene {
+	s := scene{Width: 12 + 2*sceneMargin, Height: 9 + 2*sceneMargin}
+	for _, placement := range model.NetLayout {
+		left := sceneMargin + float64(placement.Col*3)
+		top := sceneMargin + float64(placement.Row*3)
+		s.drawFace(c.Face(placement.Face), scheme, func(u, v float64) point {
+			return point{left + u, top + v}
+		})
+	}
+	return s
+}
+
+// isometricLayout shows the Up, Front and Right faces of the cube seen from
+// the corner between them, with the cube standing on its Down face
+func isometricLayout(c *model.Cube, scheme model.ColorScheme) scene {
+	cos30, sin30 := math.Cos(math.Pi/6), 0.5
+	s := scene{Width: 6*cos30 + 2*sceneMargin, Height: 6 + 2*sceneMargin}
+
+	// Project the point a stickers toward Right, b toward Up and c toward Front
+	// from the Left-Down-Back corner of the cube
+	project := func(a, b, c float64) point {
+		return point{
+			X: sceneMargin + 3*cos30 + (a-c)*cos30,
+			Y: sceneMargin + 3 + (a+c)*sin30 - b,
+		}
+	}
+
+	// The faces are read as in the net: Up with Back at the top, Front and Right with Up at the top
+	s.drawFace(c.Face(model.Up), scheme, func(u, v float64) point {
+		return project(u, 3, v)
+	})
+	s.drawFace(c.Face(model.Front), scheme, func(u, v float64) point {
+		return project(u, 3-v, 3)
+	})
+	s.drawFace(c.Face(model.Right), scheme, func(u, v float64) point {
+		return project(3, 3-v, 3-u)
+	})
+	return s
+}
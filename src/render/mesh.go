@@ -0,0 +1,78 @@
+package render
+
+import "kikokai/src/model"
+
+// Size of the pieces of the exported models and the space between them, as in the WASM view
+const (
+	pieceSize = 1.0
+	pieceGap  = 0.05
+)
+
+// boxFace is one side of a piece box, in the order of the materials of the WASM view's meshes
+type boxFace struct {
+	name   string
+	face   model.FaceIndex
+	normal [3]float64
+	u, v   [3]float64 // directions along the side, with u × v = normal so that corners run counter-clockwise
+}
+
+// boxFaces lists the sides of a box in the Three.js frame: x toward Right, y Up, z toward Front
+var boxFaces = [6]boxFace{
+	{"right", model.Right, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}, [3]float64{0, 0, 1}},
+	{"left", model.Left, [3]float64{-1, 0, 0}, [3]float64{0, 0, 1}, [3]float64{0, 1, 0}},
+	{"top", model.Up, [3]float64{0, 1, 0}, [3]float64{0, 0, 1}, [3]float64{1, 0, 0}},
+	{"bottom", model.Down, [3]float64{0, -1, 0}, [3]float64{1, 0, 0}, [3]float64{0, 0, 1}},
+	{"front", model.Front, [3]float64{0, 0, 1}, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}},
+	{"back", model.Back, [3]float64{0, 0, -1}, [3]float64{0, 1, 0}, [3]float64{1, 0, 0}},
+}
+
+// corners returns the four corners of the side of a unit box centered on the origin, counter-clockwise
+func (f boxFace) corners() [4][3]float64 {
+	var corners [4][3]float64
+	for i, sign := range [4][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+		for axis := range 3 {
+			corners[i][axis] = pieceSize / 2 * (f.normal[axis] + sign[0]*f.u[axis] + sign[1]*f.v[axis])
+		}
+	}
+	return corners
+}
+
+// piece is a visible cubie of the exported model
+type piece struct {
+	name     string
+	position [3]float64
+	colors   [6]*model.Color // color of each side in the order of boxFaces, nil for the plastic body
+}
+
+// pieces lists the 26 visible cubies placed as createCubePiece places their meshes
+func pieces(c *model.Cube) []piece {
+	var list []piece
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if x == 0 && y == 0 && z == 0 {
+					continue
+				}
+
+				// Three.js x is the model's z index, and Three.js z the model's x index
+				pos := model.CubeCoordinate{X: z + 1, Y: y + 1, Z: x + 1}
+				p := piece{
+					name:     "piece",
+					position: [3]float64{float64(x) * (pieceSize + pieceGap), float64(y) * (pieceSize + pieceGap), float64(z) * (pieceSize + pieceGap)},
+				}
+				if cubie := c.Cubies[pos.X][pos.Y][pos.Z]; cubie != nil {
+					if home, ok := c.PieceHome(pos); ok {
+						p.name = model.PieceName(home)
+					}
+					for i, side := range boxFaces {
+						if color, ok := cubie.Colors[side.face]; ok && model.IsOuterFace(pos, side.face) {
+							p.colors[i] = &color
+						}
+					}
+				}
+				list = append(list, p)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,77 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"kikokai/src/model"
+	"net/url"
+)
+
+// MaterialLibrary is the file name OBJ exports expect their materials in
+const MaterialLibrary = "state.mtl"
+
+// OBJ exports the cube as a Wavefront OBJ model, an object per piece, with its materials in MaterialLibrary:
+// a predefined scheme is named in the query of the library so that its colors are the ones loaded
+func OBJ(c *model.Cube, scheme model.ColorScheme) ([]byte, error) {
+	if err := scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Rubik's cube, %s colors\n", scheme.Name)
+	fmt.Fprintf(&buf, "mtllib %s\n", materialLibrary(scheme))
+	for _, side := range boxFaces {
+		fmt.Fprintf(&buf, "vn %g %g %g\n", side.normal[0], side.normal[1], side.normal[2])
+	}
+
+	vertices := 0
+	for _, p := range pieces(c) {
+		fmt.Fprintf(&buf, "o %s\n", p.name)
+		for i, side := range boxFaces {
+			for _, corner := range side.corners() {
+				fmt.Fprintf(&buf, "v %.4f %.4f %.4f\n", p.position[0]+corner[0], p.position[1]+corner[1], p.position[2]+corner[2])
+			}
+
+			material := "body"
+			if color := p.colors[i]; color != nil {
+				material = scheme.Color(*color).Name
+			}
+			fmt.Fprintf(&buf, "usemtl %s\n", material)
+			// Indices start at 1 and count every vertex written so far
+			fmt.Fprintf(&buf, "f %d//%d %d//%d %d//%d %d//%d\n",
+				vertices+1, i+1, vertices+2, i+1, vertices+3, i+1, vertices+4, i+1)
+			vertices += 4
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// materialLibrary refers to the materials of the scheme, or to those of the scheme in use for a custom one
+func materialLibrary(scheme model.ColorScheme) string {
+	if predefined, ok := model.ColorSchemes[scheme.Name]; ok && predefined == scheme {
+		return MaterialLibrary + "?scheme=" + url.QueryEscape(scheme.Name)
+	}
+	return MaterialLibrary
+}
+
+// MTL returns the materials of the OBJ exports: the plastic body and the colors of the scheme
+func MTL(scheme model.ColorScheme) ([]byte, error) {
+	if err := scheme.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeMaterial := func(name, hex string) {
+		rgb := model.SchemeColor{Hex: hex}.RGB()
+		fmt.Fprintf(&buf, "newmtl %s\n", name)
+		fmt.Fprintf(&buf, "Kd %.4f %.4f %.4f\n", float64(rgb>>16&0xFF)/255, float64(rgb>>8&0xFF)/255, float64(rgb&0xFF)/255)
+		buf.WriteString("Ks 0.1 0.1 0.1\nNs 50\nillum 2\n\n")
+	}
+
+	fmt.Fprintf(&buf, "# Rubik's cube, %s colors\n\n", scheme.Name)
+	writeMaterial("body", bodyColor)
+	for _, color := range scheme.Colors {
+		writeMaterial(color.Name, color.Hex)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,140 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"kikokai/src/model"
+	"math"
+)
+
+// samples is the number of samples taken across each pixel, in both directions,
+// to smooth the edges of the stickers
+const samples = 4
+
+// Image draws the cube on a transparent image
+func Image(c *model.Cube, opts Options) (*image.RGBA, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	return rasterize(layout(c, opts.View, opts.Scheme), opts.Size), nil
+}
+
+// rasterize draws a scene on a transparent image of the given width
+func rasterize(s scene, width int) *image.RGBA {
+	scale := float64(width) / s.Width
+	height := int(math.Round(s.Height * scale))
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for _, p := range s.Polygons {
+		points := make([]point, len(p.Points))
+		for i, pt := range p.Points {
+			points[i] = point{pt.X * scale, pt.Y * scale}
+		}
+		fillPolygon(img, points, parseHex(p.Fill))
+
+		if p.Letter != "" {
+			center := p.center()
+			drawLetter(img, p.Letter, point{center.X * scale, center.Y * scale}, scale*0.5, parseHex(p.LetterFill))
+		}
+	}
+	return img
+}
+
+// PNG draws the cube as a PNG picture
+func PNG(c *model.Cube, opts Options) ([]byte, error) {
+	img, err := Image(c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseHex reads a #RRGGBB color
+func parseHex(hex string) color.RGBA {
+	rgb := model.SchemeColor{Hex: hex}.RGB()
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
+
+// fillPolygon paints a convex polygon, covering each pixel in proportion to the samples inside it.
+// Each row of samples crosses the polygon along one span, so only the samples in it are counted.
+func fillPolygon(img *image.RGBA, points []point, fill color.RGBA) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, pt := range points {
+		minX, maxX = math.Min(minX, pt.X), math.Max(maxX, pt.X)
+		minY, maxY = math.Min(minY, pt.Y), math.Max(maxY, pt.Y)
+	}
+	bounds := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	mask := image.NewAlpha(bounds)
+	coverage := make([]int, bounds.Dx())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		clear(coverage)
+		for sy := range samples {
+			left, right, ok := span(points, float64(y)+(float64(sy)+0.5)/samples)
+			if !ok {
+				continue
+			}
+			// Samples of a pixel sit at x + (sx+0.5)/samples: count those from left to right
+			first := int(math.Ceil((left-float64(bounds.Min.X))*samples - 0.5))
+			last := int(math.Floor((right-float64(bounds.Min.X))*samples - 0.5))
+			first = max(first, 0)
+			last = min(last, len(coverage)*samples-1)
+			for sample := first; sample <= last; sample++ {
+				coverage[sample/samples]++
+			}
+		}
+		for x, inside := range coverage {
+			mask.SetAlpha(bounds.Min.X+x, y, color.Alpha{A: uint8(inside * 0xFF / (samples * samples))})
+		}
+	}
+	draw.DrawMask(img, bounds, image.NewUniform(fill), image.Point{}, mask, bounds.Min, draw.Over)
+}
+
+// span returns where a horizontal line crosses a convex polygon, from left to right
+func span(points []point, y float64) (left, right float64, ok bool) {
+	left, right = math.Inf(1), math.Inf(-1)
+	for i, a := range points {
+		b := points[(i+1)%len(points)]
+		if (a.Y > y) == (b.Y > y) {
+			// The edge does not cross the line, but a horizontal edge on it bounds the span
+			if a.Y == y && b.Y == y {
+				left, right = math.Min(left, math.Min(a.X, b.X)), math.Max(right, math.Max(a.X, b.X))
+			}
+			continue
+		}
+		x := a.X + (y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		left, right = math.Min(left, x), math.Max(right, x)
+	}
+	return left, right, left <= right
+}
+
+// drawLetter writes a letter of the bitmap font centered on a point, height pixels high
+func drawLetter(img *image.RGBA, letter string, center point, height float64, fill color.RGBA) {
+	g := glyph(letter)
+	pixel := height / glyphHeight
+	left := center.X - pixel*glyphWidth/2
+	top := center.Y - height/2
+
+	for row, line := range g {
+		for col, c := range line {
+			if c != '#' {
+				continue
+			}
+			x, y := left+float64(col)*pixel, top+float64(row)*pixel
+			fillPolygon(img, []point{{x, y}, {x + pixel, y}, {x + pixel, y + pixel}, {x, y + pixel}}, fill)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"kikokai/src/model"
+	"testing"
+)
+
+func TestPNG_Decodes(t *testing.T) {
+	opts := DefaultOptions()
+	opts.View = ViewIsometric
+	opts.Size = 200
+	data, err := PNG(model.NewCube(), opts)
+	if err != nil {
+		t.Fatalf("PNG failed: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("PNG does not decode: %v", err)
+	}
+	if width := img.Bounds().Dx(); width != 200 {
+		t.Errorf("width = %d, want 200", width)
+	}
+}
+
+func TestImage_StickerColors(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Size = 12 * 20
+	img, err := Image(model.NewCube(), opts)
+	if err != nil {
+		t.Fatalf("Image failed: %v", err)
+	}
+
+	// The middle of the center sticker of each face in the net has that face's color
+	scale := float64(opts.Size) / (12 + 2*sceneMargin)
+	for _, placement := range model.NetLayout {
+		x := int((sceneMargin + float64(placement.Col*3) + 1.5) * scale)
+		y := int((sceneMargin + float64(placement.Row*3) + 1.5) * scale)
+		want := parseHex(opts.Scheme.Color(model.NewCube().Face(placement.Face)[1][1]).Hex)
+		if got := img.RGBAAt(x, y); got != want {
+			t.Errorf("center of face %d at (%d, %d) is %v, want %v", placement.Face, x, y, got, want)
+		}
+	}
+
+	// Outside the cube the picture is transparent
+	if got := img.RGBAAt(1, 1); got.A != 0 {
+		t.Errorf("corner of the net is %v, want transparent", got)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	diamond := []point{{1, 0}, {2, 1}, {1, 2}, {0, 1}}
+	if left, right, ok := span(diamond, 0.5); !ok || left != 0.5 || right != 1.5 {
+		t.Errorf("span at 0.5 = %v, %v, %v; want 0.5, 1.5", left, right, ok)
+	}
+	if _, _, ok := span(diamond, 2.5); ok {
+		t.Error("a line below the diamond should not cross it")
+	}
+
+	// The same diamond in the other winding
+	reversed := []point{{0, 1}, {1, 2}, {2, 1}, {1, 0}}
+	if left, right, ok := span(reversed, 1); !ok || left != 0 || right != 2 {
+		t.Errorf("span of the reversed diamond at 1 = %v, %v, %v; want 0, 2", left, right, ok)
+	}
+}
+
+func TestFillPolygon_Coverage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	// A square covering pixel (1,1) and the left half of pixel (2,1)
+	fillPolygon(img, []point{{1, 1}, {2.5, 1}, {2.5, 2}, {1, 2}}, color.RGBA{R: 255, A: 255})
+	if got := img.RGBAAt(1, 1).A; got != 255 {
+		t.Errorf("covered pixel has alpha %d, want 255", got)
+	}
+	if got := img.RGBAAt(2, 1).A; got < 120 || got > 135 {
+		t.Errorf("half covered pixel has alpha %d, want about 128", got)
+	}
+	if got := img.RGBAAt(3, 1).A; got != 0 {
+		t.Errorf("pixel outside has alpha %d, want 0", got)
+	}
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"fmt"
+	"kikokai/src/model"
+	"strings"
+)
+
+// View is the way the cube is laid out in a picture
+type View string
+
+const (
+	// ViewNet unfolds the six faces into a cross, as the net view of the page
+	ViewNet View = "net"
+	// ViewIsometric shows the Up, Front and Right faces of the cube seen from a corner
+	ViewIsometric View = "isometric"
+)
+
+const (
+	// DefaultSize is the width in pixels of a picture when none is asked for
+	DefaultSize = 480
+	MinSize     = 16
+	// MaxSize keeps a picture within a fraction of a second: pictures are supersampled 16 times
+	MaxSize = 1024
+)
+
+// Options describe the picture to render
+type Options struct {
+	View   View
+	Size   int               // width in pixels, the height follows from the view
+	Scheme model.ColorScheme // colors of the stickers, and whether to write their letters
+}
+
+// DefaultOptions returns the options of a net of the default size in the current color scheme
+func DefaultOptions() Options {
+	return Options{
+		View:   ViewNet,
+		Size:   DefaultSize,
+		Scheme: model.CurrentColorScheme(),
+	}
+}
+
+// ParseView returns the view with the given name; "iso" is short for isometric
+func ParseView(name string) (View, error) {
+	switch strings.ToLower(name) {
+	case "", string(ViewNet):
+		return ViewNet, nil
+	case "iso", string(ViewIsometric):
+		return ViewIsometric, nil
+	default:
+		return "", fmt.Errorf("unknown view %q, expected net or isometric", name)
+	}
+}
+
+// validate checks that the options describe a picture that can be drawn
+func (o Options) validate() error {
+	if o.Size < MinSize || o.Size > MaxSize {
+		return fmt.Errorf("size must be between %d and %d pixels, got %d", MinSize, MaxSize, o.Size)
+	}
+	if _, err := ParseView(string(o.View)); err != nil {
+		return err
+	}
+	return o.Scheme.Validate()
+}
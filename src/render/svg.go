@@ -0,0 +1,42 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"kikokai/src/model"
+	"strings"
+)
+
+// SVG draws the cube as an SVG document
+func SVG(c *model.Cube, opts Options) ([]byte, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	s := layout(c, opts.View, opts.Scheme)
+	width := float64(opts.Size)
+	height := width * s.Height / s.Width
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.4f %.4f">`+"\n",
+		width, height, s.Width, s.Height)
+	fmt.Fprintf(&buf, "<title>Rubik's cube, %s view, %s colors</title>\n", opts.View, html.EscapeString(opts.Scheme.Name))
+
+	for _, p := range s.Polygons {
+		points := make([]string, len(p.Points))
+		for i, pt := range p.Points {
+			points[i] = fmt.Sprintf("%.4f,%.4f", pt.X, pt.Y)
+		}
+		fmt.Fprintf(&buf, `<polygon points="%s" fill="%s"/>`+"\n", strings.Join(points, " "), p.Fill)
+
+		if p.Letter != "" {
+			center := p.center()
+			fmt.Fprintf(&buf, `<text x="%.4f" y="%.4f" fill="%s" font-family="sans-serif" font-weight="bold" font-size="0.5" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+				center.X, center.Y, p.LetterFill, html.EscapeString(p.Letter))
+		}
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,100 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"kikokai/src/model"
+	"testing"
+)
+
+func TestSVG_ParsesAsXML(t *testing.T) {
+	tests := []struct {
+		view     View
+		polygons int
+	}{
+		{ViewNet, 6 * 10},
+		{ViewIsometric, 3 * 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.view), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.View = tt.view
+			svg, err := SVG(model.NewCube(), opts)
+			if err != nil {
+				t.Fatalf("SVG failed: %v", err)
+			}
+
+			polygons := 0
+			decoder := xml.NewDecoder(bytes.NewReader(svg))
+			for {
+				token, err := decoder.Token()
+				if err != nil {
+					break
+				}
+				if start, ok := token.(xml.StartElement); ok && start.Name.Local == "polygon" {
+					polygons++
+				}
+			}
+			if polygons != tt.polygons {
+				t.Errorf("got %d polygons, want %d", polygons, tt.polygons)
+			}
+		})
+	}
+}
+
+func TestSVG_Letters(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Scheme = model.ColorSchemes["color-blind"]
+	svg, err := SVG(model.NewCube(), opts)
+	if err != nil {
+		t.Fatalf("SVG failed: %v", err)
+	}
+	if n := bytes.Count(svg, []byte("<text")); n != 54 {
+		t.Errorf("got %d letters, want 54", n)
+	}
+}
+
+func TestSVG_InvalidOptions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Size = 0
+	if _, err := SVG(model.NewCube(), opts); err == nil {
+		t.Error("expected an error for a zero size")
+	}
+
+	opts = DefaultOptions()
+	opts.View = "top"
+	if _, err := SVG(model.NewCube(), opts); err == nil {
+		t.Error("expected an error for an unknown view")
+	}
+}
+
+func TestIsometricLayout_FrontAfterR(t *testing.T) {
+	c := model.NewCube()
+	if err := c.ApplyMove(model.Move{Axis: "z", Layer: 1, Direction: 1}); err != nil {
+		t.Fatalf("ApplyMove failed: %v", err)
+	}
+	scheme := model.ColorSchemes[model.DefaultColorScheme]
+	s := isometricLayout(c, scheme)
+
+	// Up body and stickers, then the Front body: its top-right sticker comes from Down
+	front := s.Polygons[11:20]
+	if got, want := front[2].Fill, scheme.Color(model.Green).Hex; got != want {
+		t.Errorf("top-right front sticker is %s, want %s", got, want)
+	}
+	if got, want := front[0].Fill, scheme.Color(model.White).Hex; got != want {
+		t.Errorf("top-left front sticker is %s, want %s", got, want)
+	}
+}
+
+func TestParseView(t *testing.T) {
+	for name, want := range map[string]View{"": ViewNet, "net": ViewNet, "iso": ViewIsometric, "Isometric": ViewIsometric} {
+		got, err := ParseView(name)
+		if err != nil || got != want {
+			t.Errorf("ParseView(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseView("top"); err == nil {
+		t.Error("expected an error for an unknown view")
+	}
+}
@@ -0,0 +1,205 @@
+package render
+
+import (
+	"kikokai/src/model"
+	"math"
+	"slices"
+)
+
+// vec3 is a point of the cube in stickers, measured toward Right, Up and Front
+// from the Left-Down-Back corner; the cube spans 0 to 3 along each axis
+type vec3 [3]float64
+
+func (v vec3) add(w vec3) vec3      { return vec3{v[0] + w[0], v[1] + w[1], v[2] + w[2]} }
+func (v vec3) sub(w vec3) vec3      { return vec3{v[0] - w[0], v[1] - w[1], v[2] - w[2]} }
+func (v vec3) scale(k float64) vec3 { return vec3{v[0] * k, v[1] * k, v[2] * k} }
+func (v vec3) dot(w vec3) float64   { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+func (v vec3) cross(w vec3) vec3 {
+	return vec3{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
+
+// viewDirection points from the cube toward the viewer of the isometric view
+var viewDirection = vec3{1, 1, 1}
+
+// cubeCenter is the point the layers turn around
+var cubeCenter = vec3{1.5, 1.5, 1.5}
+
+// faceNormals gives the outward normal of each face in the coordinates of vec3
+var faceNormals = map[model.FaceIndex]vec3{
+	model.Right: {1, 0, 0},
+	model.Left:  {-1, 0, 0},
+	model.Up:    {0, 1, 0},
+	model.Down:  {0, -1, 0},
+	model.Front: {0, 0, 1},
+	model.Back:  {0, 0, -1},
+}
+
+// project places a point of the cube in the isometric view, as isometricLayout does
+func project(v vec3) point {
+	cos30, sin30 := math.Cos(math.Pi/6), 0.5
+	return point{
+		X: sceneMargin + 3*cos30 + (v[0]-v[2])*cos30,
+		Y: sceneMargin + 3 + (v[0]+v[2])*sin30 - v[1],
+	}
+}
+
+// rotate turns a point by angle radians around an axis through the center of the cube,
+// counter-clockwise when looking from the tip of the axis
+func rotate(v, axis vec3, angle float64) vec3 {
+	if angle == 0 {
+		return v
+	}
+	p := v.sub(cubeCenter)
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	rotated := p.scale(cos).add(axis.cross(p).scale(sin)).add(axis.scale(axis.dot(p) * (1 - cos)))
+	return rotated.add(cubeCenter)
+}
+
+// quad is one face of a cubie, as the four corners of its body and of its sticker
+type quad struct {
+	normal  vec3
+	body    [4]vec3
+	sticker [4]vec3
+	color   model.Color
+	colored bool // faces inside the cube have no sticker
+}
+
+// cubieQuads returns the faces of the cubie at the given indices of Cube.Cubies
+func cubieQuads(c *model.Cube, pos model.CubeCoordinate) []quad {
+	// The cubie at Cubies[x][y][z] spans z to z+1 toward Right, y to y+1 toward Up and x to x+1 toward Front
+	origin := vec3{float64(pos.Z), float64(pos.Y), float64(pos.X)}
+	cubie := c.Cubies[pos.X][pos.Y][pos.Z]
+
+	var quads []quad
+	for face, normal := range faceNormals {
+		// Two unit vectors spanning the face, with the normal as third direction
+		var u, v vec3
+		for i := range 3 {
+			if normal[i] == 0 {
+				if u == (vec3{}) {
+					u[i] = 1
+				} else {
+					v[i] = 1
+				}
+			}
+		}
+		corner := origin
+		for i := range 3 {
+			if normal[i] > 0 {
+				corner[i]++
+			}
+		}
+
+		q := quad{normal: normal}
+		at := func(s, t float64) vec3 { return corner.add(u.scale(s)).add(v.scale(t)) }
+		q.body = [4]vec3{at(0, 0), at(1, 0), at(1, 1), at(0, 1)}
+		lo, hi := stickerGap, 1-stickerGap
+		q.sticker = [4]vec3{at(lo, lo), at(hi, lo), at(hi, hi), at(lo, hi)}
+		if cubie != nil && model.IsOuterFace(pos, face) {
+			q.color, q.colored = cubie.Colors[face], true
+		}
+		quads = append(quads, q)
+	}
+	return quads
+}
+
+// turningLayer returns the outward normal of the face turned by a move,
+// and whether the cubie at the given indices turns with it
+func turningLayer(m model.Move, pos model.CubeCoordinate) (vec3, bool) {
+	index := 0
+	if m.Layer == 1 {
+		index = 2
+	}
+	var normal vec3
+	var turns bool
+	switch m.Axis {
+	case "x":
+		normal, turns = vec3{0, 0, float64(m.Layer)}, pos.X == index
+	case "y":
+		normal, turns = vec3{0, float64(m.Layer), 0}, pos.Y == index
+	case "z":
+		normal, turns = vec3{float64(m.Layer), 0, 0}, pos.Z == index
+	}
+	return normal, turns
+}
+
+// turnLayout shows the cube in the isometric view while a move is under way, the turning
+// layer rotated by the given fraction of a quarter turn
+func turnLayout(c *model.Cube, scheme model.ColorScheme, m model.Move, progress float64) scene {
+	s := scene{Width: 6*math.Cos(math.Pi/6) + 2*sceneMargin, Height: 6 + 2*sceneMargin}
+
+	// Clockwise as seen from the turning face is clockwise around its outward normal
+	angle := -float64(m.Direction) * progress * math.Pi / 2
+
+	type piece struct {
+		pos   model.CubeCoordinate
+		slab  int // layer along the axis of the move, drawn from the back to the front
+		depth float64
+		axis  vec3
+		turns bool
+	}
+	var pieces []piece
+	for x := range 3 {
+		for y := range 3 {
+			for z := range 3 {
+				pos := model.CubeCoordinate{X: x, Y: y, Z: z}
+				axis, turns := turningLayer(m, pos)
+				slab := map[string]int{"x": x, "y": y, "z": z}[m.Axis]
+				center := vec3{float64(z) + 0.5, float64(y) + 0.5, float64(x) + 0.5}
+				if turns {
+					center = rotate(center, axis, angle)
+				}
+				pieces = append(pieces, piece{pos, slab, center.dot(viewDirection), axis, turns})
+			}
+		}
+	}
+
+	// The layers are separated by planes across the axis of the move, and the viewer looks
+	// from the side of the higher indices: draw layer by layer, then the farthest pieces first
+	slices.SortStableFunc(pieces, func(a, b piece) int {
+		if a.slab != b.slab {
+			return a.slab - b.slab
+		}
+		return int(math.Copysign(1, a.depth-b.depth))
+	})
+
+	for _, p := range pieces {
+		for _, q := range cubieQuads(c, p.pos) {
+			normal := q.normal
+			if p.turns {
+				normal = rotate(normal.add(cubeCenter), p.axis, angle).sub(cubeCenter)
+			}
+			// Faces turned away from the viewer are hidden by the front ones
+			if normal.dot(viewDirection) <= 1e-9 {
+				continue
+			}
+
+			body := polygon{Fill: bodyColor}
+			for _, v := range q.body {
+				if p.turns {
+					v = rotate(v, p.axis, angle)
+				}
+				body.Points = append(body.Points, project(v))
+			}
+			s.Polygons = append(s.Polygons, body)
+
+			if !q.colored {
+				continue
+			}
+			color := scheme.Color(q.color)
+			sticker := polygon{Fill: color.Hex}
+			for _, v := range q.sticker {
+				if p.turns {
+					v = rotate(v, p.axis, angle)
+				}
+				sticker.Points = append(sticker.Points, project(v))
+			}
+			if scheme.Letters {
+				sticker.Letter = color.Letter
+				sticker.LetterFill = color.LetterHex()
+			}
+			s.Polygons = append(s.Polygons, sticker)
+		}
+	}
+	return s
+}
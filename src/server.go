@@ -2,12 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"kikokai/src/mcp"
 	"kikokai/src/model"
+	"kikokai/src/render"
+	"kikokai/src/solver"
 	"log"
 	"mime"
 	"net/http"
+	"strconv"
 	"sync"
 )
 
@@ -15,15 +20,80 @@ import (
 
 // Request structure for axis-based rotations
 type RotateAxisRequest struct {
-	Axis      string `json:"axis"`      // "x", "y", or "z"
-	Layer     int    `json:"layer"`     // 1 or -1
-	Direction int    `json:"direction"` // 1 for clockwise, -1 for counter-clockwise
+	Axis      string `json:"axis"`             // "x", "y", or "z"
+	Layer     int    `json:"layer"`            // 1 or -1
+	Direction int    `json:"direction"`        // 1 for clockwise, -1 for counter-clockwise
+	Source    string `json:"source,omitempty"` // client that already applied the move locally
+}
+
+// Request structure for batch moves, given either in notation or as axis rotations
+type MovesRequest struct {
+	Algorithm string       `json:"algorithm,omitempty"` // e.g. "R U R' U'"
+	Moves     []model.Move `json:"moves,omitempty"`
+}
+
+type MovesResponse struct {
+	Algorithm string                `json:"algorithm"`
+	Moves     []model.Move          `json:"moves"`
+	State     [3][3][3]*model.Cubie `json:"state"`
+	Goal      model.GoalStatus      `json:"goal"` // progress toward the goal after the moves
 }
 
 type CubeStateResponse struct {
 	State [3][3][3]*model.Cubie `json:"state"`
 }
 
+// Request structure for changing the color scheme, given either by name or in full
+type SchemeRequest struct {
+	Name   string             `json:"name,omitempty"` // a predefined scheme, e.g. "japanese"
+	Scheme *model.ColorScheme `json:"scheme,omitempty"`
+}
+
+// Request structure for animating an algorithm. The animation starts from the given state,
+// or the solved cube when a scramble is given without one, or else the current cube.
+type RenderGIFRequest struct {
+	State     *[3][3][3]*model.Cubie `json:"state,omitempty"`
+	Scramble  string                 `json:"scramble,omitempty"` // applied to the starting state before the animation
+	Algorithm string                 `json:"algorithm"`          // moves to animate, e.g. "R U R' U'"
+	View      string                 `json:"view,omitempty"`     // isometric (default) or net
+	Size      int                    `json:"size,omitempty"`     // width in pixels, at most render.MaxAnimationSize
+	Scheme    string                 `json:"scheme,omitempty"`   // predefined color scheme, the current one by default
+	TurnTime  int                    `json:"turnTime,omitempty"` // milliseconds per turn
+	Frames    int                    `json:"frames,omitempty"`   // frames per turn in the isometric view
+}
+
+// Response structure for the faces of the cube, each a 3x3 grid of color names read row by row:
+// the side faces with Up at the top, Up with Back at the top and Down with Front at the top
+type FacesResponse struct {
+	Faces  map[string][3][3]string `json:"faces"`
+	Scheme string                  `json:"scheme"`
+}
+
+// Request structure for comparing two cube states. The first one is the current cube
+// by default, and the second one the solved cube unless a bookmark is named.
+type DiffRequest struct {
+	From     *[3][3][3]*model.Cubie `json:"from,omitempty"`
+	To       *[3][3][3]*model.Cubie `json:"to,omitempty"`
+	Bookmark string                 `json:"bookmark,omitempty"` // compare with a bookmark instead of To
+}
+
+// Request structure for setting the goal, given as a pattern, a full state or a bookmark
+type GoalRequest struct {
+	Pattern  string                 `json:"pattern,omitempty"` // e.g. "checkerboard", "solved" for the default goal
+	State    *[3][3][3]*model.Cubie `json:"state,omitempty"`
+	Bookmark string                 `json:"bookmark,omitempty"` // name of a saved state
+}
+
+type GoalResponse struct {
+	Goal     model.GoalStatus `json:"goal"`
+	Patterns []string         `json:"patterns"`
+}
+
+type SchemeResponse struct {
+	Scheme    model.ColorScheme `json:"scheme"`
+	Available []string          `json:"available"`
+}
+
 // Event types for SSE
 type CubeEvent struct {
 	Type      string                `json:"type"`
@@ -31,6 +101,10 @@ type CubeEvent struct {
 	Layer     int                   `json:"layer,omitempty"`     // 1 ou -1
 	Direction int                   `json:"direction,omitempty"` // 1 pour sens horaire, -1 pour sens anti-horaire
 	State     [3][3][3]*model.Cubie `json:"state,omitempty"`
+	Moves     []model.Move          `json:"moves,omitempty"`  // moves to animate in order for a sequence
+	Source    string                `json:"source,omitempty"` // client that already applied the move locally
+	Scheme    *model.ColorScheme    `json:"scheme,omitempty"` // color scheme now in use
+	Goal      *model.GoalStatus     `json:"goal,omitempty"`   // progress toward the goal
 }
 
 // EventBroker manages SSE connections
@@ -105,8 +179,9 @@ func (eb *EventBroker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
-	// Create a channel for this client
-	messageChan := make(chan []byte)
+	// Create a channel for this client, buffered since the broker drops clients that are not ready:
+	// a change of the cube comes with a goal event right behind it
+	messageChan := make(chan []byte, 16)
 
 	// Register this client
 	eb.register <- messageChan
@@ -124,6 +199,18 @@ func (eb *EventBroker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		State: model.SharedCube.Cubies,
 	})
 	fmt.Fprintf(w, "data: %s\n\n", initialState)
+	scheme := model.CurrentColorScheme()
+	initialScheme, _ := json.Marshal(CubeEvent{
+		Type:   "scheme",
+		Scheme: &scheme,
+	})
+	fmt.Fprintf(w, "data: %s\n\n", initialScheme)
+	goal := model.GoalProgress(model.SharedCube)
+	initialGoal, _ := json.Marshal(CubeEvent{
+		Type: "goal",
+		Goal: &goal,
+	})
+	fmt.Fprintf(w, "data: %s\n\n", initialGoal)
 	w.(http.Flusher).Flush()
 
 	// Stream events to client
@@ -148,6 +235,22 @@ func (eb *EventBroker) BroadcastEvent(event interface{}) {
 	eb.broadcast <- data
 }
 
+// broadcastGoal sends the progress of the shared cube toward the goal,
+// then a goal_reached event when the cube has just reached it; call it after each change of the cube
+func broadcastGoal() {
+	status, reached := model.UpdateGoal(model.SharedCube)
+	broker.BroadcastEvent(CubeEvent{
+		Type: "goal",
+		Goal: &status,
+	})
+	if reached {
+		broker.BroadcastEvent(CubeEvent{
+			Type: "goal_reached",
+			Goal: &status,
+		})
+	}
+}
+
 // Create a global event broker
 var broker = NewEventBroker()
 
@@ -167,9 +270,30 @@ func main() {
 	// Setup routes
 	http.Handle("/", http.FileServer(http.Dir("static")))
 	http.HandleFunc("/api/state", handleState)
+	http.HandleFunc("POST /api/state", handleLoadState)
+	http.HandleFunc("GET /api/faces", handleFaces)
+	http.HandleFunc("GET /api/pieces", handlePieces)
+	http.HandleFunc("POST /api/diff", handleDiff)
+	http.HandleFunc("GET /api/state.svg", handleStateSVG)
+	http.HandleFunc("GET /api/state.png", handleStatePNG)
+	http.HandleFunc("GET /api/state.txt", handleStateText)
+	http.HandleFunc("POST /api/render/gif", handleRenderGIF)
+	http.HandleFunc("GET /api/state.gltf", handleStateGLTF)
+	http.HandleFunc("GET /api/state.obj", handleStateOBJ)
+	http.HandleFunc("GET /api/"+render.MaterialLibrary, handleStateMTL)
 	http.HandleFunc("/api/rotate-axis", handleRotate) // Nouvelle route pour la rotation par axe
 	http.HandleFunc("/api/reset", handleReset)
 	http.HandleFunc("/api/scramble", handleScramble)
+	http.HandleFunc("/api/moves", handleMoves)
+	http.HandleFunc("GET /api/history", handleHistory)
+	http.HandleFunc("GET /api/hint", handleHint)
+	http.HandleFunc("GET /api/stages", handleStages)
+	http.HandleFunc("GET /api/goal", handleGoal)
+	http.HandleFunc("POST /api/goal", handleSetGoal)
+	http.HandleFunc("GET /api/scheme", handleScheme)
+	http.HandleFunc("POST /api/scheme", handleSetScheme)
+	http.HandleFunc("/api/bookmarks", handleBookmarks)
+	http.HandleFunc("POST /api/bookmarks/{name}/restore", handleRestoreBookmark)
 	http.Handle("/api/events", broker)
 
 	// Start MCP server in a goroutine
@@ -198,6 +322,361 @@ func handleState(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Return the six faces of the cube as grids of color names
+func handleFaces(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling faces request")
+
+	scheme := model.CurrentColorScheme()
+	response := FacesResponse{
+		Faces:  model.SharedCube.FaceNames(scheme),
+		Scheme: scheme.Name,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding faces response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Locate every corner and edge, or only the piece given by its colors, e.g. ?piece=white-green-orange
+func handlePieces(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling pieces request")
+
+	var response any
+	if name := r.URL.Query().Get("piece"); name != "" {
+		location, err := model.SharedCube.LocatePiece(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response = location
+	} else {
+		locations, err := model.SharedCube.Pieces()
+		if err != nil {
+			log.Printf("Error locating pieces: %v", err)
+			http.Error(w, "Failed to locate pieces: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response = struct {
+			Pieces []model.PieceLocation `json:"pieces"`
+		}{locations}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding pieces response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Replace the shared cube with a state painted in the editor, once validated
+func handleLoadState(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling load state request")
+
+	var req CubeStateResponse
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding state request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := model.LoadCube(&model.Cube{Cubies: req.State}); err != nil {
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			if err := json.NewEncoder(w).Encode(validationErr); err != nil {
+				log.Printf("Error encoding validation response: %v", err)
+			}
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Broadcast the full state so that browsers redraw the loaded cube
+	broker.BroadcastEvent(CubeEvent{
+		Type:  "state",
+		State: model.SharedCube.Cubies,
+	})
+	broadcastGoal()
+
+	// Return the updated state
+	handleState(w, r)
+}
+
+// Compare the pieces of two states, by default the current cube against the solved one
+func handleDiff(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling diff request")
+
+	var req DiffRequest
+	// An empty body compares the current cube with the solved one
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("Error decoding diff request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	from := model.SharedCube
+	if req.From != nil {
+		var err error
+		if from, err = model.CubeFromState(*req.From); err != nil {
+			http.Error(w, "Invalid from state: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	to := model.NewCube()
+	switch {
+	case req.Bookmark != "" && req.To != nil:
+		http.Error(w, "Give either a bookmark or a to state, not both", http.StatusBadRequest)
+		return
+	case req.Bookmark != "":
+		var err error
+		if to, err = model.BookmarkCube(req.Bookmark); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	case req.To != nil:
+		var err error
+		if to, err = model.CubeFromState(*req.To); err != nil {
+			http.Error(w, "Invalid to state: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	diff, err := model.Diff(from, to)
+	if err != nil {
+		http.Error(w, "Failed to compare the states: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(diff); err != nil {
+		log.Printf("Error encoding diff response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// renderOptions reads the view, size and color scheme of a picture from the query,
+// e.g. ?view=isometric&size=300&scheme=japanese
+func renderOptions(r *http.Request) (render.Options, error) {
+	query := r.URL.Query()
+	size := 0
+	if value := query.Get("size"); value != "" {
+		var err error
+		size, err = strconv.Atoi(value)
+		if err != nil {
+			return render.Options{}, fmt.Errorf("invalid size %q", value)
+		}
+	}
+	return parseRenderOptions(render.DefaultOptions(), query.Get("view"), size, query.Get("scheme"))
+}
+
+// parseRenderOptions changes the options given a view, a size and a color scheme name,
+// each left as it is when empty or zero
+func parseRenderOptions(opts render.Options, view string, size int, scheme string) (render.Options, error) {
+	if view != "" {
+		parsed, err := render.ParseView(view)
+		if err != nil {
+			return opts, err
+		}
+		opts.View = parsed
+	}
+	if size != 0 {
+		opts.Size = size
+	}
+	if scheme != "" {
+		predefined, err := model.LookupColorScheme(scheme)
+		if err != nil {
+			return opts, err
+		}
+		opts.Scheme = predefined
+	}
+	return opts, nil
+}
+
+// Render the current cube as an SVG picture
+func handleStateSVG(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling SVG state request")
+
+	opts, err := renderOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	svg, err := render.SVG(model.SharedCube, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(svg); err != nil {
+		log.Printf("Error writing SVG response: %v", err)
+	}
+}
+
+// Render the current cube as a PNG picture
+func handleStatePNG(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling PNG state request")
+
+	opts, err := renderOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	picture, err := render.PNG(model.SharedCube, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(picture); err != nil {
+		log.Printf("Error writing PNG response: %v", err)
+	}
+}
+
+// Export the current cube as a glTF 2.0 model
+func handleStateGLTF(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling glTF export request")
+	writeExport(w, r, "model/gltf+json", "state.gltf", func(scheme model.ColorScheme) ([]byte, error) {
+		return render.GLTF(model.SharedCube, scheme)
+	})
+}
+
+// Export the current cube as a Wavefront OBJ model, whose materials are served next to it
+func handleStateOBJ(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling OBJ export request")
+	writeExport(w, r, "model/obj", "state.obj", func(scheme model.ColorScheme) ([]byte, error) {
+		return render.OBJ(model.SharedCube, scheme)
+	})
+}
+
+// Serve the materials of the OBJ export
+func handleStateMTL(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling MTL export request")
+	writeExport(w, r, "model/mtl", render.MaterialLibrary, render.MTL)
+}
+
+// writeExport sends a model of the cube in the color scheme given by the query, if any,
+// as a file to download
+func writeExport(w http.ResponseWriter, r *http.Request, contentType, filename string, export func(model.ColorScheme) ([]byte, error)) {
+	opts, err := parseRenderOptions(render.DefaultOptions(), "", 0, r.URL.Query().Get("scheme"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := export(opts.Scheme)
+	if err != nil {
+		log.Printf("Error exporting %s: %v", filename, err)
+		http.Error(w, "Failed to export the cube: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Error writing %s: %v", filename, err)
+	}
+}
+
+// Write the current cube as a text net, e.g. ?style=ansi for a terminal
+func handleStateText(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling text state request")
+
+	style, err := model.ParseTextStyle(r.URL.Query().Get("style"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opts, err := parseRenderOptions(render.DefaultOptions(), "", 0, r.URL.Query().Get("scheme"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := fmt.Fprint(w, model.SharedCube.RenderScheme(style, opts.Scheme)); err != nil {
+		log.Printf("Error writing text state response: %v", err)
+	}
+}
+
+// Animate an algorithm as a GIF picture
+func handleRenderGIF(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling GIF render request")
+
+	var req RenderGIFRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding GIF render request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	opts := render.DefaultAnimationOptions()
+	var err error
+	opts.Options, err = parseRenderOptions(opts.Options, req.View, req.Size, req.Scheme)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Frames != 0 {
+		opts.TurnFrames = req.Frames
+	}
+	if req.TurnTime != 0 {
+		opts.TurnDelay = req.TurnTime / 10
+	}
+
+	var start *model.Cube
+	switch {
+	case req.State != nil:
+		start, err = model.CubeFromState(*req.State)
+		if err != nil {
+			http.Error(w, "Invalid starting state: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	case req.Scramble != "":
+		start = model.NewCube()
+	default:
+		start = model.SharedCube.Clone()
+	}
+
+	if req.Scramble != "" {
+		scramble, err := model.ParseAlgorithm(req.Scramble)
+		if err != nil {
+			http.Error(w, "Invalid scramble: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		start.ApplyMoves(scramble)
+	}
+
+	moves, err := model.ParseAlgorithm(req.Algorithm)
+	if err != nil {
+		http.Error(w, "Invalid algorithm: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	picture, err := render.GIF(start, moves, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/gif")
+	if _, err := w.Write(picture); err != nil {
+		log.Printf("Error writing GIF response: %v", err)
+	}
+}
+
 func handleReset(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling reset request")
 
@@ -208,6 +687,7 @@ func handleReset(w http.ResponseWriter, r *http.Request) {
 	broker.BroadcastEvent(CubeEvent{
 		Type: "reset",
 	})
+	broadcastGoal()
 
 	// Return the updated state
 	handleState(w, r)
@@ -218,11 +698,14 @@ func handleScramble(w http.ResponseWriter, r *http.Request) {
 
 	// Scramble the cube using the new structure
 	model.SharedCube.Scramble(20) // Scramble with 20 random moves
+	model.RestartHistory()
 
-	// Broadcast the scramble event
+	// Broadcast the scrambled state, for browsers to show the cube the server turned
 	broker.BroadcastEvent(CubeEvent{
-		Type: "scramble",
+		Type:  "scramble",
+		State: model.SharedCube.Cubies,
 	})
+	broadcastGoal()
 
 	// Return the updated state
 	handleState(w, r)
@@ -232,11 +715,7 @@ func handleRotate(w http.ResponseWriter, r *http.Request) {
 	log.Println("Handling rotate request")
 
 	// Parse the request body
-	var req struct {
-		Axis      string `json:"axis"`
-		Layer     int    `json:"layer"`
-		Direction int    `json:"direction"`
-	}
+	var req RotateAxisRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("Error decoding rotate request: %v", err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -263,6 +742,7 @@ func handleRotate(w http.ResponseWriter, r *http.Request) {
 
 	// Apply the rotation to the cube
 	model.SharedCube.RotateAxis(face, clockwise)
+	model.RecordMoves(model.Move{Axis: req.Axis, Layer: req.Layer, Direction: req.Direction})
 
 	// Broadcast the rotation event
 	broker.BroadcastEvent(CubeEvent{
@@ -270,7 +750,299 @@ func handleRotate(w http.ResponseWriter, r *http.Request) {
 		Axis:      req.Axis,
 		Layer:     req.Layer,
 		Direction: req.Direction,
+		Source:    req.Source,
+	})
+	broadcastGoal()
+
+	// Return the updated state
+	handleState(w, r)
+}
+
+func handleMoves(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling moves request")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req MovesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding moves request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	moves := req.Moves
+	if req.Algorithm != "" {
+		if len(req.Moves) > 0 {
+			http.Error(w, "Provide either an algorithm or a list of moves, not both", http.StatusBadRequest)
+			return
+		}
+		var err error
+		if moves, err = model.ParseAlgorithm(req.Algorithm); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// All moves are validated before the first one is applied
+	if err := model.SharedCube.ApplyMoves(moves); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	model.RecordMoves(moves...)
+
+	// Broadcast the whole batch as a single sequence
+	if len(moves) > 0 {
+		broker.BroadcastEvent(CubeEvent{
+			Type:  "sequence",
+			Moves: moves,
+		})
+		broadcastGoal()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(MovesResponse{
+		Algorithm: model.FormatAlgorithm(moves),
+		Moves:     moves,
+		State:     model.SharedCube.Cubies,
+		Goal:      model.GoalProgress(model.SharedCube),
+	}); err != nil {
+		log.Printf("Error encoding moves response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Return the moves applied since the cube was last reset, scrambled or replaced,
+// with the state they started from, so that browsers can replay them
+func handleHistory(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling history request")
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(model.MoveHistory()); err != nil {
+		log.Printf("Error encoding history response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Return the next move toward the solved cube and the number of face turns left
+func handleHint(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling hint request")
+
+	hint, err := solver.NextMove(model.SharedCube)
+	if err != nil {
+		log.Printf("Error computing hint: %v", err)
+		http.Error(w, "Failed to compute hint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hint); err != nil {
+		log.Printf("Error encoding hint response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Check the solving stages on the current cube, or only the one given by ?stage=f2l
+func handleStages(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling stages request")
+
+	var response any
+	if name := r.URL.Query().Get("stage"); name != "" {
+		stage, err := model.FindStage(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		status, err := stage.Check(model.SharedCube)
+		if err != nil {
+			http.Error(w, "Failed to check the stage: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response = status
+	} else {
+		statuses, err := model.SharedCube.CheckStages()
+		if err != nil {
+			http.Error(w, "Failed to check the stages: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response = struct {
+			Stages []model.StageStatus `json:"stages"`
+		}{statuses}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding stages response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Return the progress of the cube toward the goal, and the patterns available as goals
+func handleGoal(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling goal request")
+
+	response := GoalResponse{
+		Goal:     model.GoalProgress(model.SharedCube),
+		Patterns: model.PatternNames(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding goal response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Set the goal to a pattern or a full state, then report the progress toward it
+func handleSetGoal(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling set goal request")
+
+	var req GoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding goal request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case req.Pattern != "" && (req.State != nil || req.Bookmark != "") || req.State != nil && req.Bookmark != "":
+		http.Error(w, "Provide one of a pattern, a state or a bookmark", http.StatusBadRequest)
+		return
+	case req.State != nil:
+		cube, err := model.CubeFromState(*req.State)
+		if err != nil {
+			http.Error(w, "Invalid goal state: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		model.SetGoal(model.CustomGoal, cube)
+	case req.Bookmark != "":
+		cube, err := model.BookmarkCube(req.Bookmark)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		model.SetGoal(model.CustomGoal, cube)
+	default:
+		pattern := req.Pattern
+		if pattern == "" {
+			pattern = model.SolvedGoal
+		}
+		if err := model.SetGoalPattern(pattern); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	broadcastGoal()
+	handleGoal(w, r)
+}
+
+func handleScheme(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling color scheme request")
+
+	response := SchemeResponse{
+		Scheme:    model.CurrentColorScheme(),
+		Available: model.ColorSchemeNames(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("Error encoding color scheme response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// Change the color scheme used by every rendering of the cube
+func handleSetScheme(w http.ResponseWriter, r *http.Request) {
+	log.Println("Handling set color scheme request")
+
+	var req SchemeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding color scheme request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	switch {
+	case req.Scheme != nil:
+		err = model.SetColorScheme(*req.Scheme)
+	case req.Name != "":
+		err = model.UseColorScheme(req.Name)
+	default:
+		err = errors.New("give either a scheme name or a scheme")
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	scheme := model.CurrentColorScheme()
+	broker.BroadcastEvent(CubeEvent{
+		Type:   "scheme",
+		Scheme: &scheme,
+	})
+
+	handleScheme(w, r)
+}
+
+func handleBookmarks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		log.Println("Handling list bookmarks request")
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(model.Bookmarks()); err != nil {
+			log.Printf("Error encoding bookmarks response: %v", err)
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		}
+
+	case http.MethodPost:
+		log.Println("Handling save bookmark request")
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Printf("Error decoding bookmark request: %v", err)
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		bookmark, err := model.SaveBookmark(req.Name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(bookmark); err != nil {
+			log.Printf("Error encoding bookmark response: %v", err)
+		}
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func handleRestoreBookmark(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	log.Printf("Handling restore bookmark request: %s", name)
+
+	if err := model.RestoreBookmark(name); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	// Broadcast the full state so that browsers redraw the restored cube
+	broker.BroadcastEvent(CubeEvent{
+		Type:  "state",
+		State: model.SharedCube.Cubies,
 	})
+	broadcastGoal()
 
 	// Return the updated state
 	handleState(w, r)
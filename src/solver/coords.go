@@ -0,0 +1,170 @@
+package solver
+
+// Coordinates number the states of parts of the cube, so that turning faces can be
+// looked up in tables. Phase 1 uses the corner twist, the edge flip and the places of
+// the four middle slice edges; phase 2 the corner permutation, the permutation of the
+// eight U and D edges and the permutation of the slice edges. Each is 0 when solved.
+const (
+	twistCount      = 2187  // 3^7
+	flipCount       = 2048  // 2^11
+	sliceCount      = 495   // 12 choose 4
+	cornerPermCount = 40320 // 8!
+	edge8PermCount  = 40320 // 8!
+	slicePermCount  = 24    // 4!
+)
+
+// First slice edge (FR) in model.EdgeSlots; FR, FL, BL and BR sit in the middle layer
+const firstSliceEdge = 8
+
+func (c *cubieCube) twist() int {
+	t := 0
+	for i := range 7 {
+		t = 3*t + c.co[i]
+	}
+	return t
+}
+
+// setTwist sets the corner orientations, the last corner making the total twist a multiple of 3
+func (c *cubieCube) setTwist(t int) {
+	sum := 0
+	for i := 6; i >= 0; i-- {
+		c.co[i] = t % 3
+		sum += c.co[i]
+		t /= 3
+	}
+	c.co[7] = (3 - sum%3) % 3
+}
+
+func (c *cubieCube) flip() int {
+	f := 0
+	for i := range 11 {
+		f = 2*f + c.eo[i]
+	}
+	return f
+}
+
+// setFlip sets the edge orientations, the last edge making the total flip even
+func (c *cubieCube) setFlip(f int) {
+	sum := 0
+	for i := 10; i >= 0; i-- {
+		c.eo[i] = f % 2
+		sum += c.eo[i]
+		f /= 2
+	}
+	c.eo[11] = sum % 2
+}
+
+// sliceMasks lists the sets of four edge slots, as bit masks, in decreasing order so that
+// the solved places of the slice edges (the four highest slots) come first
+var sliceMasks, sliceIndex = func() ([]int, [1 << 12]int) {
+	var masks []int
+	var index [1 << 12]int
+	for mask := 1<<12 - 1; mask >= 0; mask-- {
+		if bitCount(mask) == 4 {
+			index[mask] = len(masks)
+			masks = append(masks, mask)
+		}
+	}
+	return masks, index
+}()
+
+func bitCount(mask int) int {
+	count := 0
+	for ; mask != 0; mask &= mask - 1 {
+		count++
+	}
+	return count
+}
+
+// slice numbers the slots holding the slice edges, whatever their order
+func (c *cubieCube) slice() int {
+	mask := 0
+	for i, edge := range c.ep {
+		if edge >= firstSliceEdge {
+			mask |= 1 << i
+		}
+	}
+	return sliceIndex[mask]
+}
+
+// setSlice puts the slice edges in the given slots, in order, and the other edges in the remaining slots
+func (c *cubieCube) setSlice(s int) {
+	mask := sliceMasks[s]
+	slice, other := firstSliceEdge, 0
+	for i := range c.ep {
+		if mask&(1<<i) != 0 {
+			c.ep[i] = slice
+			slice++
+		} else {
+			c.ep[i] = other
+			other++
+		}
+	}
+}
+
+func (c *cubieCube) cornerPerm() int {
+	return rank(c.cp[:])
+}
+
+func (c *cubieCube) setCornerPerm(r int) {
+	unrank(r, c.cp[:])
+}
+
+// edge8Perm numbers the order of the U and D edges, which must be in the U and D layers
+func (c *cubieCube) edge8Perm() int {
+	return rank(c.ep[:firstSliceEdge])
+}
+
+func (c *cubieCube) setEdge8Perm(r int) {
+	unrank(r, c.ep[:firstSliceEdge])
+}
+
+// slicePerm numbers the order of the slice edges, which must be in the middle layer
+func (c *cubieCube) slicePerm() int {
+	var perm [4]int
+	for i := range perm {
+		perm[i] = c.ep[firstSliceEdge+i] - firstSliceEdge
+	}
+	return rank(perm[:])
+}
+
+func (c *cubieCube) setSlicePerm(r int) {
+	unrank(r, c.ep[firstSliceEdge:])
+	for i := firstSliceEdge; i < 12; i++ {
+		c.ep[i] += firstSliceEdge
+	}
+}
+
+// rank numbers a permutation of 0..n-1 by its Lehmer code; the identity is 0
+func rank(perm []int) int {
+	r := 0
+	for i := range perm {
+		smaller := 0
+		for j := i + 1; j < len(perm); j++ {
+			if perm[j] < perm[i] {
+				smaller++
+			}
+		}
+		r = r*(len(perm)-i) + smaller
+	}
+	return r
+}
+
+// unrank fills perm with the permutation of 0..n-1 numbered r
+func unrank(r int, perm []int) {
+	n := len(perm)
+	codes := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		codes[i] = r % (n - i)
+		r /= n - i
+	}
+
+	unused := make([]int, n)
+	for i := range unused {
+		unused[i] = i
+	}
+	for i, code := range codes {
+		perm[i] = unused[code]
+		unused = append(unused[:code], unused[code+1:]...)
+	}
+}
@@ -0,0 +1,76 @@
+package solver
+
+import "kikokai/src/model"
+
+// cubieCube describes the cube by the place and orientation of its corners and edges,
+// numbered as in model.CornerSlots and model.EdgeSlots
+type cubieCube struct {
+	cp [8]int  // corner found in each corner slot
+	co [8]int  // twist of the corner in each slot
+	ep [12]int // edge found in each edge slot
+	eo [12]int // flip of the edge in each slot
+}
+
+// solvedCubie is the solved cube
+var solvedCubie = cubieCube{
+	cp: [8]int{0, 1, 2, 3, 4, 5, 6, 7},
+	ep: [12]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+}
+
+func fromPieceState(state model.PieceState) cubieCube {
+	return cubieCube{
+		cp: state.CornerPermutation,
+		co: state.CornerOrientation,
+		ep: state.EdgePermutation,
+		eo: state.EdgeOrientation,
+	}
+}
+
+// multiply returns the cube obtained by applying the moves giving b to the cube a
+func (a cubieCube) multiply(b cubieCube) cubieCube {
+	var c cubieCube
+	for i := range 8 {
+		c.cp[i] = a.cp[b.cp[i]]
+		c.co[i] = (a.co[b.cp[i]] + b.co[i]) % 3
+	}
+	for i := range 12 {
+		c.ep[i] = a.ep[b.ep[i]]
+		c.eo[i] = (a.eo[b.ep[i]] + b.eo[i]) % 2
+	}
+	return c
+}
+
+// Faces in the order used to number the moves: move m turns faceLetters[m/3]
+// by a quarter turn clockwise, a half turn or a quarter turn counter-clockwise for m%3 = 0, 1, 2
+const faceLetters = "URFDLB"
+
+const moveCount = 18
+
+// moveCubes holds the cube obtained by applying each of the 18 face turns to the solved cube.
+// They are read from the model, so that the solver turns faces exactly as the model does.
+var moveCubes = func() [moveCount]cubieCube {
+	var cubes [moveCount]cubieCube
+	for face := range 6 {
+		quarter, err := model.ParseAlgorithm(faceLetters[face : face+1])
+		if err != nil {
+			panic(err)
+		}
+		cube := model.NewCube()
+		cube.ApplyMoves(quarter)
+		state, err := cube.PieceState()
+		if err != nil {
+			panic(err)
+		}
+		turn := fromPieceState(state)
+
+		cubes[face*3] = turn
+		cubes[face*3+1] = turn.multiply(turn)
+		cubes[face*3+2] = cubes[face*3+1].multiply(turn)
+	}
+	return cubes
+}()
+
+// moveNotation returns move m in standard face notation
+func moveNotation(m int) string {
+	return faceLetters[m/3:m/3+1] + [3]string{"", "2", "'"}[m%3]
+}
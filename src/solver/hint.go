@@ -0,0 +1,38 @@
+package solver
+
+import (
+	"kikokai/src/model"
+	"strings"
+)
+
+// Hint suggests the next move toward the solved cube
+type Hint struct {
+	Solved   bool         `json:"solved"`
+	Move     string       `json:"move,omitempty"`  // next face turn in standard notation, e.g. "R2"
+	Moves    []model.Move `json:"moves,omitempty"` // quarter turns making up the next face turn
+	Distance int          `json:"distance"`        // face turns in the shortest solution found within SearchTimeout, not always the optimum
+	Solution string       `json:"solution,omitempty"`
+}
+
+// NextMove solves the cube and returns the first move of the solution
+func NextMove(c *model.Cube) (Hint, error) {
+	solution, err := Solve(c)
+	if err != nil {
+		return Hint{}, err
+	}
+
+	turns := strings.Fields(solution)
+	if len(turns) == 0 {
+		return Hint{Solved: true}, nil
+	}
+	moves, err := model.ParseAlgorithm(turns[0])
+	if err != nil {
+		return Hint{}, err
+	}
+	return Hint{
+		Move:     turns[0],
+		Moves:    moves,
+		Distance: len(turns),
+		Solution: solution,
+	}, nil
+}
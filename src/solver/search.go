@@ -0,0 +1,150 @@
+package solver
+
+import (
+	"errors"
+	"kikokai/src/model"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SearchTimeout bounds the time spent looking for shorter solutions once one has been found.
+// Cubes a few moves from solved are solved optimally well before it.
+var SearchTimeout = 300 * time.Millisecond
+
+const (
+	maxPhase1Depth = 12
+	maxPhase2Depth = 18
+)
+
+// ErrNoSolution is returned when the search ends without finding a solution
+var ErrNoSolution = errors.New("no solution found")
+
+// search holds the state of a two-phase search: phase 1 brings the cube into the group
+// generated by U, D, R2, L2, F2 and B2, phase 2 solves it using only those moves
+type search struct {
+	start    cubieCube
+	path     []int // moves of the current branch, phase 1 then phase 2
+	best     []int // shortest solution found so far
+	limit    int   // maximum total length still worth searching
+	deadline time.Time
+}
+
+// Solve returns a sequence of face turns, in standard notation with half turns written
+// as R2, bringing the cube back to the solved state. The cube must be valid.
+func Solve(c *model.Cube) (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	state, err := c.PieceState()
+	if err != nil {
+		return "", err
+	}
+	buildTables()
+
+	s := &search{
+		start:    fromPieceState(state),
+		limit:    maxPhase1Depth + maxPhase2Depth,
+		deadline: time.Now().Add(SearchTimeout),
+	}
+	twist, flip, slice := s.start.twist(), s.start.flip(), s.start.slice()
+	for depth := 0; depth <= min(maxPhase1Depth, s.limit); depth++ {
+		if s.searchPhase1(twist, flip, slice, depth) {
+			break
+		}
+	}
+	if s.best == nil {
+		return "", ErrNoSolution
+	}
+
+	notation := make([]string, len(s.best))
+	for i, m := range s.best {
+		notation[i] = moveNotation(m)
+	}
+	return strings.Join(notation, " "), nil
+}
+
+// done reports whether the search can stop with the best solution found so far
+func (s *search) done() bool {
+	return s.best != nil && time.Now().After(s.deadline)
+}
+
+// allowed reports whether move m may follow the last move of the path:
+// never the same face twice, and opposite faces in one order only
+func (s *search) allowed(m int) bool {
+	if len(s.path) == 0 {
+		return true
+	}
+	face, last := m/3, s.path[len(s.path)-1]/3
+	return face != last && !(face%3 == last%3 && face < last)
+}
+
+// searchPhase1 extends the path by depth moves reaching phase 2, and returns true once the search is done
+func (s *search) searchPhase1(twist, flip, slice, depth int) bool {
+	if depth == 0 {
+		// A phase 1 solution ending with a phase 2 move was already tried without that move
+		if twist != 0 || flip != 0 || slice != 0 ||
+			(len(s.path) > 0 && slices.Contains(phase2Moves, s.path[len(s.path)-1])) {
+			return false
+		}
+		s.startPhase2()
+		return s.done()
+	}
+	if max(twistSlicePrune[twist*sliceCount+slice], flipSlicePrune[flip*sliceCount+slice]) > int8(depth) {
+		return false
+	}
+
+	for m := range moveCount {
+		if !s.allowed(m) {
+			continue
+		}
+		s.path = append(s.path, m)
+		done := s.searchPhase1(int(twistMove[twist][m]), int(flipMove[flip][m]), int(sliceMove[slice][m]), depth-1)
+		s.path = s.path[:len(s.path)-1]
+		if done {
+			return true
+		}
+	}
+	return false
+}
+
+// startPhase2 looks for the shortest phase 2 solution making the path shorter than the best one
+func (s *search) startPhase2() {
+	c := s.start
+	for _, m := range s.path {
+		c = c.multiply(moveCubes[m])
+	}
+
+	phase1 := len(s.path)
+	cornerPerm, edge8Perm, slicePerm := c.cornerPerm(), c.edge8Perm(), c.slicePerm()
+	for depth := 0; depth <= min(maxPhase2Depth, s.limit-phase1); depth++ {
+		if s.searchPhase2(cornerPerm, edge8Perm, slicePerm, depth) {
+			s.best = append([]int{}, s.path...)
+			s.limit = len(s.best) - 1
+			s.path = s.path[:phase1]
+			return
+		}
+	}
+}
+
+// searchPhase2 extends the path by depth phase 2 moves solving the cube, leaving them on the path when found
+func (s *search) searchPhase2(cornerPerm, edge8Perm, slicePerm, depth int) bool {
+	if depth == 0 {
+		return cornerPerm == 0 && edge8Perm == 0 && slicePerm == 0
+	}
+	if max(cornerSlicePermPrune[cornerPerm*slicePermCount+slicePerm], edge8SlicePermPrune[edge8Perm*slicePermCount+slicePerm]) > int8(depth) {
+		return false
+	}
+
+	for _, m := range phase2Moves {
+		if !s.allowed(m) {
+			continue
+		}
+		s.path = append(s.path, m)
+		if s.searchPhase2(int(cornerPermMove[cornerPerm][m]), int(edge8PermMove[edge8Perm][m]), int(slicePermMove[slicePerm][m]), depth-1) {
+			return true
+		}
+		s.path = s.path[:len(s.path)-1]
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package solver
+
+import (
+	"kikokai/src/model"
+	"strings"
+	"testing"
+)
+
+func TestMoveCubes_MatchModel(t *testing.T) {
+	// Multiplying the move cubes follows the model turning its stickers
+	algorithm := "R U F' D2 L B' R2 U'"
+	moves, err := model.ParseAlgorithm(algorithm)
+	if err != nil {
+		t.Fatalf("ParseAlgorithm failed: %v", err)
+	}
+	cube := model.NewCube()
+	cube.ApplyMoves(moves)
+	state, err := cube.PieceState()
+	if err != nil {
+		t.Fatalf("PieceState failed: %v", err)
+	}
+
+	c := solvedCubie
+	for _, token := range strings.Fields(algorithm) {
+		c = c.multiply(moveCubes[moveIndex(t, token)])
+	}
+	if c != fromPieceState(state) {
+		t.Errorf("Cubie cube after %q = %+v, want %+v", algorithm, c, fromPieceState(state))
+	}
+}
+
+func moveIndex(t *testing.T, token string) int {
+	for m := range moveCount {
+		if moveNotation(m) == token {
+			return m
+		}
+	}
+	t.Fatalf("unknown move %q", token)
+	return 0
+}
+
+func TestCoordinates_RoundTrip(t *testing.T) {
+	for twist := range twistCount {
+		c := solvedCubie
+		c.setTwist(twist)
+		if got := c.twist(); got != twist {
+			t.Fatalf("twist() after setTwist(%d) = %d", twist, got)
+		}
+	}
+	for flip := range flipCount {
+		c := solvedCubie
+		c.setFlip(flip)
+		if got := c.flip(); got != flip {
+			t.Fatalf("flip() after setFlip(%d) = %d", flip, got)
+		}
+	}
+	for slice := range sliceCount {
+		c := solvedCubie
+		c.setSlice(slice)
+		if got := c.slice(); got != slice {
+			t.Fatalf("slice() after setSlice(%d) = %d", slice, got)
+		}
+	}
+	for _, perm := range []int{0, 1, 5039, 40319} {
+		c := solvedCubie
+		c.setCornerPerm(perm)
+		if got := c.cornerPerm(); got != perm {
+			t.Fatalf("cornerPerm() after setCornerPerm(%d) = %d", perm, got)
+		}
+	}
+	for perm := range slicePermCount {
+		c := solvedCubie
+		c.setSlicePerm(perm)
+		if got := c.slicePerm(); got != perm {
+			t.Fatalf("slicePerm() after setSlicePerm(%d) = %d", perm, got)
+		}
+	}
+
+	solved := solvedCubie
+	if solved.twist() != 0 || solved.flip() != 0 || solved.slice() != 0 ||
+		solved.cornerPerm() != 0 || solved.edge8Perm() != 0 || solved.slicePerm() != 0 {
+		t.Error("Every coordinate of the solved cube should be 0")
+	}
+}
+
+func TestSolve_Solved(t *testing.T) {
+	solution, err := Solve(model.NewCube())
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if solution != "" {
+		t.Errorf("Solve(solved) = %q, want no moves", solution)
+	}
+}
+
+func TestSolve_Scrambles(t *testing.T) {
+	solvedJSON, _ := model.NewCube().ToReadableJSON()
+	for range 5 {
+		cube := model.NewCube()
+		cube.Scramble(40)
+
+		solution, err := Solve(cube)
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if length := len(strings.Fields(solution)); length > maxPhase1Depth+maxPhase2Depth {
+			t.Errorf("Solution %q has %d moves, more than the search allows", solution, length)
+		}
+
+		moves, err := model.ParseAlgorithm(solution)
+		if err != nil {
+			t.Fatalf("Solution %q does not parse: %v", solution, err)
+		}
+		cube.ApplyMoves(moves)
+		if got, _ := cube.ToReadableJSON(); got != solvedJSON {
+			t.Errorf("Solution %q does not solve the cube", solution)
+		}
+	}
+}
+
+func TestSolve_Invalid(t *testing.T) {
+	cube := model.NewCube()
+	cube.Cubies[2][2][1].Colors[model.Up], cube.Cubies[2][2][1].Colors[model.Front] =
+		cube.Cubies[2][2][1].Colors[model.Front], cube.Cubies[2][2][1].Colors[model.Up]
+	if _, err := Solve(cube); err == nil {
+		t.Error("Solve should fail for a cube with a flipped edge")
+	}
+}
+
+func TestNextMove(t *testing.T) {
+	hint, err := NextMove(model.NewCube())
+	if err != nil || !hint.Solved {
+		t.Fatalf("NextMove(solved) = %+v, %v; want solved", hint, err)
+	}
+
+	cube := model.NewCube()
+	moves, _ := model.ParseAlgorithm("R U'")
+	cube.ApplyMoves(moves)
+	hint, err = NextMove(cube)
+	if err != nil {
+		t.Fatalf("NextMove failed: %v", err)
+	}
+	if hint.Move != "U" || hint.Distance != 2 || len(hint.Moves) != 1 {
+		t.Errorf("NextMove after R U' = %+v, want U with 2 moves left", hint)
+	}
+}
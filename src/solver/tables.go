@@ -0,0 +1,120 @@
+package solver
+
+import "sync"
+
+// phase2Moves are the turns keeping the cube in phase 2: any turn of U and D, half turns of the others
+var phase2Moves = []int{0, 1, 2, 4, 7, 9, 10, 11, 13, 16}
+
+// Tables giving each coordinate after a move, and the minimum number of moves to solve
+// pairs of coordinates. They take a moment to build and are built on first use.
+var (
+	tablesOnce sync.Once
+
+	twistMove      [twistCount][moveCount]uint16
+	flipMove       [flipCount][moveCount]uint16
+	sliceMove      [sliceCount][moveCount]uint16
+	cornerPermMove [cornerPermCount][moveCount]uint16
+	edge8PermMove  [edge8PermCount][moveCount]uint16 // phase 2 moves only
+	slicePermMove  [slicePermCount][moveCount]uint16 // phase 2 moves only
+
+	twistSlicePrune      []int8
+	flipSlicePrune       []int8
+	cornerSlicePermPrune []int8
+	edge8SlicePermPrune  []int8
+)
+
+func buildTables() {
+	tablesOnce.Do(func() {
+		for t := range twistCount {
+			c := solvedCubie
+			c.setTwist(t)
+			for m := range moveCount {
+				d := c.multiply(moveCubes[m])
+				twistMove[t][m] = uint16(d.twist())
+			}
+		}
+		for f := range flipCount {
+			c := solvedCubie
+			c.setFlip(f)
+			for m := range moveCount {
+				d := c.multiply(moveCubes[m])
+				flipMove[f][m] = uint16(d.flip())
+			}
+		}
+		for s := range sliceCount {
+			c := solvedCubie
+			c.setSlice(s)
+			for m := range moveCount {
+				d := c.multiply(moveCubes[m])
+				sliceMove[s][m] = uint16(d.slice())
+			}
+		}
+		for p := range cornerPermCount {
+			c := solvedCubie
+			c.setCornerPerm(p)
+			for m := range moveCount {
+				d := c.multiply(moveCubes[m])
+				cornerPermMove[p][m] = uint16(d.cornerPerm())
+			}
+		}
+		for p := range edge8PermCount {
+			c := solvedCubie
+			c.setEdge8Perm(p)
+			for _, m := range phase2Moves {
+				d := c.multiply(moveCubes[m])
+				edge8PermMove[p][m] = uint16(d.edge8Perm())
+			}
+		}
+		for p := range slicePermCount {
+			c := solvedCubie
+			c.setSlicePerm(p)
+			for _, m := range phase2Moves {
+				d := c.multiply(moveCubes[m])
+				slicePermMove[p][m] = uint16(d.slicePerm())
+			}
+		}
+
+		allMoves := make([]int, moveCount)
+		for m := range allMoves {
+			allMoves[m] = m
+		}
+		twistSlicePrune = buildPruning(twistCount, sliceCount, lookup(twistMove[:]), lookup(sliceMove[:]), allMoves)
+		flipSlicePrune = buildPruning(flipCount, sliceCount, lookup(flipMove[:]), lookup(sliceMove[:]), allMoves)
+		cornerSlicePermPrune = buildPruning(cornerPermCount, slicePermCount, lookup(cornerPermMove[:]), lookup(slicePermMove[:]), phase2Moves)
+		edge8SlicePermPrune = buildPruning(edge8PermCount, slicePermCount, lookup(edge8PermMove[:]), lookup(slicePermMove[:]), phase2Moves)
+	})
+}
+
+// buildPruning finds, by a breadth-first search from the solved state, the number of moves
+// needed to solve each pair of coordinates (a, b), stored at a*sizeB+b
+func buildPruning(sizeA, sizeB int, moveA, moveB func(coord, m int) int, moves []int) []int8 {
+	table := make([]int8, sizeA*sizeB)
+	for i := range table {
+		table[i] = -1
+	}
+	table[0] = 0
+
+	for depth, filled := int8(0), 1; filled < len(table); depth++ {
+		for i, d := range table {
+			if d != depth {
+				continue
+			}
+			a, b := i/sizeB, i%sizeB
+			for _, m := range moves {
+				j := moveA(a, m)*sizeB + moveB(b, m)
+				if table[j] == -1 {
+					table[j] = depth + 1
+					filled++
+				}
+			}
+		}
+	}
+	return table
+}
+
+// lookup returns a coordinate move function reading the given table
+func lookup[T ~[moveCount]uint16](table []T) func(coord, m int) int {
+	return func(coord, m int) int {
+		return int(table[coord][m])
+	}
+}
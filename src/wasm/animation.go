@@ -13,11 +13,6 @@ import (
 // Rotate a face of the cube
 func rotateFace(this js.Value, args []js.Value) any {
 	// Enhanced parameter validation
-	if isAnimating {
-		println("Animation already in progress, ignoring rotation request")
-		return js.ValueOf("Animation in progress")
-	}
-
 	if len(args) < 2 {
 		println("Error: Not enough arguments to rotateFace, expected 2, got", len(args))
 		return js.ValueOf("Invalid arguments: expected face index and clockwise boolean")
@@ -55,15 +50,49 @@ func rotateFace(this js.Value, args []js.Value) any {
 		}
 	}
 
-	println("Starting rotation of face", int(face), "with clockwise value:", clockwise == model.Clockwise)
-	isAnimating = true
+	// Queue the turn, it starts right away when no other turn is animating
+	if isAnimating {
+		println("Animation in progress, queuing rotation of face", int(face), "with clockwise value:", clockwise == model.Clockwise)
+		enqueueTurn(face, clockwise)
+		return js.ValueOf("Animation queued")
+	}
 
-	// Start animation
-	go animateFaceRotation(face, clockwise)
+	println("Starting rotation of face", int(face), "with clockwise value:", clockwise == model.Clockwise)
+	enqueueTurn(face, clockwise)
 
 	return js.ValueOf("Animation started")
 }
 
+// Rotate a layer given as axis, layer and direction, the same way as the server API
+func rotateAxis(this js.Value, args []js.Value) any {
+	if len(args) < 3 {
+		println("Error: Not enough arguments to rotateAxis, expected 3, got", len(args))
+		return js.ValueOf("Invalid arguments: expected axis, layer and direction")
+	}
+
+	move := model.Move{Axis: args[0].String(), Layer: args[1].Int(), Direction: args[2].Int()}
+	if err := move.Validate(); err != nil {
+		println("Error: Invalid axis rotation:", err.Error())
+		return js.ValueOf("Invalid axis rotation")
+	}
+
+	return rotateFace(this, []js.Value{
+		js.ValueOf(int(moveToFace(move))),
+		js.ValueOf(move.Direction == 1),
+	})
+}
+
+// moveToFace returns the face turned by a move
+func moveToFace(move model.Move) model.FaceIndex {
+	coord := model.GetCoordFromAxis(move.Axis, move.Layer)
+	for face := model.Front; face <= model.Down; face++ {
+		if model.FaceToCoordinate(face) == coord {
+			return face
+		}
+	}
+	return model.Front
+}
+
 // Animate the rotation of a face
 func animateFaceRotation(face model.FaceIndex, clockwise model.TurningDirection) {
 	// Log start of animation
@@ -109,29 +138,40 @@ func animateFaceRotation(face model.FaceIndex, clockwise model.TurningDirection)
 	// Convert the CubeCoordinate to a Three.js Vector3 object
 	jsRotationAxis := getRotationAxis(rotationAxis)
 
-	// Define rotation parameters based on direction
-	var rotationAngle float64
-	var targetRotation float64
-
-	// Always use positive values and adjust sign based on direction
+	// Negative angles turn clockwise when looking at the face
+	targetRotation := math.Pi / 2 // +90 degrees
 	if clockwise == model.Clockwise {
-		rotationAngle = -0.1          // Negative for clockwise
 		targetRotation = -math.Pi / 2 // -90 degrees
-	} else {
-		rotationAngle = 0.1          // Positive for counter-clockwise
-		targetRotation = math.Pi / 2 // +90 degrees
 	}
 
-	totalRotation := float64(0)
+	// The queue speed shortens the turns when many are waiting
+	duration := animationDuration / queueSpeed
+	easing := easingFunctions[animationEasing]
+	startTime := -1.0
 
 	// Set up animation callback
 	var animateFrame js.Func
 	animateFrame = js.FuncOf(func(this js.Value, args []js.Value) any {
-		// Check if we've reached the target rotation amount
-		if math.Abs(totalRotation) < math.Abs(targetRotation) {
+		// requestAnimationFrame passes the current time in milliseconds
+		now := js.Global().Get("performance").Call("now").Float()
+		if len(args) > 0 && args[0].Type() == js.TypeNumber {
+			now = args[0].Float()
+		}
+		if startTime < 0 {
+			startTime = now
+		}
+
+		progress := 1.0
+		if duration > 0 {
+			progress = math.Min((now-startTime)/duration, 1)
+		}
+
+		// Set the angle from the elapsed time rather than adding a step per frame,
+		// so that the speed does not depend on the refresh rate and the last frame lands exactly on 90°
+		rotationGroup.Call("setRotationFromAxisAngle", jsRotationAxis, targetRotation*easing(progress))
+
+		if progress < 1 {
 			// Continue animation
-			rotationGroup.Call("rotateOnAxis", jsRotationAxis, rotationAngle)
-			totalRotation += rotationAngle
 			js.Global().Call("requestAnimationFrame", animateFrame)
 		} else {
 			// Animation complete - cleanup
@@ -153,6 +193,7 @@ func animateFaceRotation(face model.FaceIndex, clockwise model.TurningDirection)
 
 			// Update the model
 			cube.RotateAxis(rotationAxis, clockwise)
+			recordTurn()
 
 			// Log cube state after update
 			stateAfterJSON, _ := json.Marshal(cube.Cubies)
@@ -166,6 +207,9 @@ func animateFaceRotation(face model.FaceIndex, clockwise model.TurningDirection)
 
 			isAnimating = false
 			println("Animation and model update completed for face", int(face))
+
+			// Play the next queued action
+			processQueue()
 		}
 		return nil
 	})
@@ -17,6 +17,34 @@ func registerCallbacks() {
 	scrambleCubeFunc := js.FuncOf(scrambleCube)
 	addCoordinateAxesFunc := js.FuncOf(addCoordinateAxes)
 	updateCubeFromStateFunc := js.FuncOf(updateCubeFromState)
+	rotateAxisFunc := js.FuncOf(rotateAxis)
+	setQueueOptionsFunc := js.FuncOf(setQueueOptions)
+	getQueueLengthFunc := js.FuncOf(getQueueLength)
+	setAnimationSpeedFunc := js.FuncOf(setAnimationSpeed)
+	setKeyMapFunc := js.FuncOf(setKeyMap)
+	getKeyMapFunc := js.FuncOf(getKeyMap)
+	setKeyboardEnabledFunc := js.FuncOf(setKeyboardEnabled)
+	getClientIDFunc := js.FuncOf(getClientID)
+	setCameraPresetFunc := js.FuncOf(setCameraPreset)
+	resetViewFunc := js.FuncOf(resetView)
+	setEditModeFunc := js.FuncOf(setEditMode)
+	setPaintColorFunc := js.FuncOf(setPaintColor)
+	validateCubeFunc := js.FuncOf(validateCube)
+	sendStateToServerFunc := js.FuncOf(sendStateToServer)
+	loadReplayFunc := js.FuncOf(loadReplay)
+	playReplayFunc := js.FuncOf(playReplay)
+	pauseReplayFunc := js.FuncOf(pauseReplayCallback)
+	stepReplayFunc := js.FuncOf(stepReplayCallback)
+	seekReplayFunc := js.FuncOf(seekReplayCallback)
+	getReplayStatusFunc := js.FuncOf(getReplayStatus)
+	closeReplayFunc := js.FuncOf(closeReplay)
+	showHintFunc := js.FuncOf(showHint)
+	hideHintFunc := js.FuncOf(hideHint)
+	lastLayerCaseFunc := js.FuncOf(lastLayerCase)
+	trackPieceFunc := js.FuncOf(trackPiece)
+	setTrackLengthFunc := js.FuncOf(setTrackLength)
+	setColorSchemeFunc := js.FuncOf(setColorScheme)
+	getColorSchemeFunc := js.FuncOf(getColorScheme)
 
 	// Register functions in the global namespace
 	js.Global().Set("wasmInitThreeScene", initThreeSceneFunc)
@@ -26,6 +54,34 @@ func registerCallbacks() {
 	js.Global().Set("wasmScrambleCube", scrambleCubeFunc)
 	js.Global().Set("wasmAddCoordinateAxes", addCoordinateAxesFunc)
 	js.Global().Set("wasmUpdateCubeFromState", updateCubeFromStateFunc)
+	js.Global().Set("wasmRotateAxis", rotateAxisFunc)
+	js.Global().Set("wasmSetQueueOptions", setQueueOptionsFunc)
+	js.Global().Set("wasmGetQueueLength", getQueueLengthFunc)
+	js.Global().Set("wasmSetAnimationSpeed", setAnimationSpeedFunc)
+	js.Global().Set("wasmSetKeyMap", setKeyMapFunc)
+	js.Global().Set("wasmGetKeyMap", getKeyMapFunc)
+	js.Global().Set("wasmSetKeyboardEnabled", setKeyboardEnabledFunc)
+	js.Global().Set("wasmGetClientId", getClientIDFunc)
+	js.Global().Set("wasmSetCameraPreset", setCameraPresetFunc)
+	js.Global().Set("wasmResetView", resetViewFunc)
+	js.Global().Set("wasmSetEditMode", setEditModeFunc)
+	js.Global().Set("wasmSetPaintColor", setPaintColorFunc)
+	js.Global().Set("wasmValidateCube", validateCubeFunc)
+	js.Global().Set("wasmSendStateToServer", sendStateToServerFunc)
+	js.Global().Set("wasmReplayLoad", loadReplayFunc)
+	js.Global().Set("wasmReplayPlay", playReplayFunc)
+	js.Global().Set("wasmReplayPause", pauseReplayFunc)
+	js.Global().Set("wasmReplayStep", stepReplayFunc)
+	js.Global().Set("wasmReplaySeek", seekReplayFunc)
+	js.Global().Set("wasmReplayStatus", getReplayStatusFunc)
+	js.Global().Set("wasmReplayClose", closeReplayFunc)
+	js.Global().Set("wasmShowHint", showHintFunc)
+	js.Global().Set("wasmHideHint", hideHintFunc)
+	js.Global().Set("wasmLastLayerCase", lastLayerCaseFunc)
+	js.Global().Set("wasmTrackPiece", trackPieceFunc)
+	js.Global().Set("wasmSetTrackLength", setTrackLengthFunc)
+	js.Global().Set("wasmSetColorScheme", setColorSchemeFunc)
+	js.Global().Set("wasmGetColorScheme", getColorSchemeFunc)
 
 	// Add a debug function to verify registration
 	debugFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
@@ -37,8 +93,14 @@ func registerCallbacks() {
 	// This is crucial - functions will be garbage collected if not stored
 	funcs = append(funcs, initThreeSceneFunc, getStateFunc, rotateFaceFunc,
 		resetCubeFunc, scrambleCubeFunc, addCoordinateAxesFunc,
-		updateCubeFromStateFunc, debugFunc)
+		updateCubeFromStateFunc, rotateAxisFunc, setQueueOptionsFunc,
+		getQueueLengthFunc, setAnimationSpeedFunc, setKeyMapFunc, getKeyMapFunc,
+		setKeyboardEnabledFunc, getClientIDFunc, setCameraPresetFunc, resetViewFunc,
+		setEditModeFunc, setPaintColorFunc, validateCubeFunc, sendStateToServerFunc,
+		loadReplayFunc, playReplayFunc, pauseReplayFunc, stepReplayFunc, seekReplayFunc,
+		getReplayStatusFunc, closeReplayFunc, showHintFunc, hideHintFunc, lastLayerCaseFunc,
+		trackPieceFunc, setTrackLengthFunc, setColorSchemeFunc, getColorSchemeFunc, debugFunc)
 
 	// Print to console that functions are registered
-	println("WASM functions registered: wasmInitThreeScene, wasmGetState, wasmRotateFace, wasmResetCube, wasmScrambleCube, wasmAddCoordinateAxes, wasmUpdateCubeFromState")
+	println("WASM functions registered: wasmInitThreeScene, wasmGetState, wasmRotateFace, wasmResetCube, wasmScrambleCube, wasmAddCoordinateAxes, wasmUpdateCubeFromState, wasmRotateAxis, wasmSetQueueOptions, wasmGetQueueLength, wasmSetAnimationSpeed, wasmSetKeyMap, wasmGetKeyMap, wasmSetKeyboardEnabled, wasmGetClientId, wasmSetCameraPreset, wasmResetView, wasmSetEditMode, wasmSetPaintColor, wasmValidateCube, wasmSendStateToServer, wasmReplayLoad, wasmReplayPlay, wasmReplayPause, wasmReplayStep, wasmReplaySeek, wasmReplayStatus, wasmReplayClose, wasmShowHint, wasmHideHint, wasmLastLayerCase, wasmTrackPiece, wasmSetTrackLength, wasmSetColorScheme, wasmGetColorScheme")
 }
@@ -0,0 +1,105 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"math"
+	"syscall/js"
+)
+
+// Size used when the container has no layout size yet
+const (
+	fallbackWidth  = 600
+	fallbackHeight = 500
+)
+
+// Distance from the camera to the center of the cube
+var cameraDistance = 4 * math.Sqrt(3)
+
+// Camera positions, as directions from the center of the cube
+var cameraPresets = map[string][3]float64{
+	"front":     {0, 0, 1},
+	"isometric": {1, 1, 1},
+	// Slightly in front of the top so that OrbitControls keeps the front face at the bottom
+	"top": {0, 1, 0.001},
+}
+
+const defaultCameraPreset = "isometric"
+
+// placeCamera moves the camera in the given direction from the center, looking at it
+func placeCamera(direction [3]float64) {
+	length := math.Sqrt(direction[0]*direction[0] + direction[1]*direction[1] + direction[2]*direction[2])
+	position := camera.Get("position")
+	position.Set("x", direction[0]/length*cameraDistance)
+	position.Set("y", direction[1]/length*cameraDistance)
+	position.Set("z", direction[2]/length*cameraDistance)
+	camera.Call("lookAt", 0, 0, 0)
+}
+
+// resizeRenderer fits the renderer and the camera to the size of the container
+func resizeRenderer(container js.Value) {
+	width := container.Get("clientWidth").Float()
+	height := container.Get("clientHeight").Float()
+	if width <= 0 || height <= 0 {
+		width, height = fallbackWidth, fallbackHeight
+	}
+
+	aspect := width / height
+	camera.Set("aspect", aspect)
+	// Zoom out on portrait screens so that the cube is not cropped on the sides
+	camera.Set("zoom", math.Min(1, aspect))
+	camera.Call("updateProjectionMatrix")
+
+	// Let CSS keep controlling the size of the canvas
+	renderer.Call("setSize", width, height, false)
+	style := renderer.Get("domElement").Get("style")
+	style.Set("width", "100%")
+	style.Set("height", "100%")
+}
+
+// Element the renderer is attached to
+var canvasContainer js.Value
+
+// setupResize keeps the renderer sized to its container
+func setupResize(container js.Value) {
+	canvasContainer = container
+	onResize := js.FuncOf(func(this js.Value, args []js.Value) any {
+		resizeRenderer(container)
+		return nil
+	})
+	funcs = append(funcs, onResize)
+
+	// The container can change size without the window resizing, e.g. when the layout changes
+	if resizeObserver := js.Global().Get("ResizeObserver"); !resizeObserver.IsUndefined() {
+		resizeObserver.New(onResize).Call("observe", container)
+		return
+	}
+	js.Global().Call("addEventListener", "resize", onResize)
+}
+
+// Move the camera to a named view: "front", "isometric" or "top"
+func setCameraPreset(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return js.ValueOf("Invalid arguments: expected a preset name")
+	}
+
+	direction, ok := cameraPresets[args[0].String()]
+	if !ok {
+		println("Error: Unknown camera preset:", args[0].String())
+		return js.ValueOf("Unknown camera preset")
+	}
+
+	controls.Get("target").Call("set", 0, 0, 0)
+	placeCamera(direction)
+	controls.Call("update")
+	return js.ValueOf("Camera moved to " + args[0].String())
+}
+
+// Restore the initial view, undoing orbiting and zooming
+func resetView(this js.Value, args []js.Value) any {
+	controls.Call("reset")
+	// The saved zoom may not match the current container anymore
+	resizeRenderer(canvasContainer)
+	return js.ValueOf("View reset")
+}
@@ -11,6 +11,9 @@ import (
 
 // Create the 3D cube from state
 func createCube() {
+	// A hint only applies to the state it was computed for
+	clearHint()
+
 	// Clear existing cube
 	for cubeGroup.Get("children").Get("length").Int() > 0 {
 		cubeGroup.Call("remove", cubeGroup.Get("children").Index(0))
@@ -29,6 +32,12 @@ func createCube() {
 			}
 		}
 	}
+
+	// Show where the tracked piece went
+	drawTrackPath()
+
+	// Keep the net view in step with the 3D cube
+	drawNet()
 }
 
 // Create a single cube piece
@@ -84,59 +93,60 @@ func createCubePiece(x, y, z int) {
 	// RIGHT face in ThreeJS (x=1)
 	if x == 1 && modelZ == 2 { // In model, Right is x=2
 		if color, ok := cubie.Colors[model.Right]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting RIGHT face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(0).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(0), color)
 		}
 	}
 
 	// LEFT face in ThreeJS (x=-1)
 	if x == -1 && modelZ == 0 { // In model, Left is x=0
 		if color, ok := cubie.Colors[model.Left]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting LEFT face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(1).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(1), color)
 		}
 	}
 
 	// TOP face in ThreeJS (y=1)
 	if y == 1 && modelY == 2 { // In model, Up is y=2
 		if color, ok := cubie.Colors[model.Up]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting UP face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(2).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(2), color)
 		}
 	}
 
 	// BOTTOM face in ThreeJS (y=-1)
 	if y == -1 && modelY == 0 { // In model, Down is y=0
 		if color, ok := cubie.Colors[model.Down]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting DOWN face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(3).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(3), color)
 		}
 	}
 
 	// FRONT face in ThreeJS (z=1)
 	if z == 1 && modelX == 2 { // In model, Front should be z=0, but based on your rotations it needs to be x=2
 		if color, ok := cubie.Colors[model.Front]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting FRONT face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(4).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(4), color)
 		}
 	}
 
 	// BACK face in ThreeJS (z=-1)
 	if z == -1 && modelX == 0 { // In model, Back should be z=2, but based on your rotations it needs to be x=0
 		if color, ok := cubie.Colors[model.Back]; ok {
-			hexColor := colorMap[color]
+			hexColor := stickerHex(color)
 			println("Setting BACK face color at", x, y, z, "to", color, "(hex:", hexColor, ")")
-			materials.Index(5).Get("color").Call("setHex", hexColor)
+			setStickerColor(materials.Index(5), color)
 		}
 	}
 
 	// Create mesh with materials
 	cubeMesh := mesh.New(geometry, materials)
+	styleTrackedPiece(cubeMesh, model.CubeCoordinate{X: modelX, Y: modelY, Z: modelZ}, materials)
 
 	// Set position in the 3D space
 	cubeMesh.Get("position").Set("x", float64(x)*(cubeSize+gap))
@@ -162,10 +172,6 @@ func getState(this js.Value, args []js.Value) any {
 
 // Update the cube state from a JSON string
 func updateCubeFromState(this js.Value, args []js.Value) any {
-	if isAnimating {
-		return js.ValueOf("Animation in progress")
-	}
-
 	if len(args) < 1 {
 		return js.ValueOf("Error: Missing state parameter")
 	}
@@ -180,34 +186,49 @@ func updateCubeFromState(this js.Value, args []js.Value) any {
 		return js.ValueOf("Error: Invalid state format")
 	}
 
-	// Update the cube state
-	cube.Cubies = cubies
+	// Update the cube state once the turns received before it have been played
+	queued := isAnimating
+	enqueueUpdate(func() {
+		cube.Cubies = cubies
+		resetTrackPath()
 
-	// Rebuild the cube visualization
-	createCube()
+		// Rebuild the cube visualization
+		createCube()
+	})
 
+	if queued {
+		return js.ValueOf("Cube state update queued")
+	}
 	return js.ValueOf("Cube state updated")
 }
 
 // Reset the cube
 func resetCube(this js.Value, args []js.Value) any {
-	if isAnimating {
-		return js.ValueOf("Animation in progress")
-	}
+	queued := isAnimating
+	enqueueUpdate(func() {
+		cube = model.NewCube()
+		resetTrackPath()
+		createCube()
+	})
 
-	cube = model.NewCube()
-	createCube()
+	if queued {
+		return js.ValueOf("Cube reset queued")
+	}
 	return js.ValueOf("Cube reset")
 }
 
 // Scramble the cube
 func scrambleCube(this js.Value, args []js.Value) any {
-	if isAnimating {
-		return js.ValueOf("Animation in progress")
-	}
+	queued := isAnimating
+	enqueueUpdate(func() {
+		// Scramble the cube with a standard number of random moves
+		cube.Scramble(20) // Scramble with 20 random moves
+		resetTrackPath()
+		createCube()
+	})
 
-	// Scramble the cube with a standard number of random moves
-	cube.Scramble(20) // Scramble with 20 random moves
-	createCube()
+	if queued {
+		return js.ValueOf("Cube scramble queued")
+	}
 	return js.ValueOf("Cube scrambled")
 }
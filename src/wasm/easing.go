@@ -0,0 +1,74 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"math"
+	"syscall/js"
+)
+
+var (
+	// Duration of a quarter turn in milliseconds
+	animationDuration float64 = 300
+
+	// Name of the easing curve applied to the turns
+	animationEasing = "easeInOutCubic"
+
+	// Easing curves mapping the elapsed fraction of a turn (0 to 1) to the fraction of the angle
+	easingFunctions = map[string]func(t float64) float64{
+		"linear": func(t float64) float64 {
+			return t
+		},
+		"easeInQuad": func(t float64) float64 {
+			return t * t
+		},
+		"easeOutQuad": func(t float64) float64 {
+			return t * (2 - t)
+		},
+		"easeInOutQuad": func(t float64) float64 {
+			if t < 0.5 {
+				return 2 * t * t
+			}
+			return 1 - math.Pow(-2*t+2, 2)/2
+		},
+		"easeOutCubic": func(t float64) float64 {
+			return 1 - math.Pow(1-t, 3)
+		},
+		"easeInOutCubic": func(t float64) float64 {
+			if t < 0.5 {
+				return 4 * t * t * t
+			}
+			return 1 - math.Pow(-2*t+2, 3)/2
+		},
+		"easeInOutSine": func(t float64) float64 {
+			return -(math.Cos(math.Pi*t) - 1) / 2
+		},
+	}
+)
+
+// Set the duration of a quarter turn in milliseconds and optionally the easing curve,
+// e.g. wasmSetAnimationSpeed(200, "easeOutCubic")
+func setAnimationSpeed(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeNumber {
+		return js.ValueOf("Invalid arguments: expected a duration in milliseconds")
+	}
+
+	duration := args[0].Float()
+	if duration < 0 {
+		return js.ValueOf("Invalid duration: must not be negative")
+	}
+
+	if len(args) > 1 && args[1].Type() == js.TypeString {
+		name := args[1].String()
+		if _, ok := easingFunctions[name]; !ok {
+			println("Error: Unknown easing curve:", name)
+			return js.ValueOf("Unknown easing curve")
+		}
+		animationEasing = name
+	}
+
+	animationDuration = duration
+	println("Animation duration set to", duration, "ms with easing", animationEasing)
+	return js.ValueOf("Animation speed updated")
+}
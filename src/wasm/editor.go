@@ -0,0 +1,155 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"math"
+	"syscall/js"
+)
+
+// cycleColors makes a click give the sticker the next color instead of the paint color
+const cycleColors = -1
+
+var (
+	// In edit mode, clicking a sticker paints it instead of turning a layer
+	editMode bool
+
+	// Color painted on the clicked stickers, or cycleColors
+	paintColor = cycleColors
+)
+
+// validationResult is the outcome of validating the edited cube, sent to JavaScript
+type validationResult struct {
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Faces of the model seen along the Three.js axes, for outward normals +x, -x, +y, -y, +z, -z
+var normalFaces = [3][2]model.FaceIndex{
+	{model.Right, model.Left},
+	{model.Up, model.Down},
+	{model.Front, model.Back},
+}
+
+// paintSticker paints the sticker hit by the pointer and reports the validation result
+func paintSticker(hit js.Value) {
+	userData := hit.Get("object").Get("userData")
+	normal := vectorToArray(hit.Get("face").Get("normal"))
+
+	// Convert from ThreeJS coordinates (-1,0,1) to model array indices (0,1,2)
+	pos := model.CubeCoordinate{
+		X: userData.Get("posZ").Int() + 1,
+		Y: userData.Get("posY").Int() + 1,
+		Z: userData.Get("posX").Int() + 1,
+	}
+
+	var face model.FaceIndex
+	for i := range 3 {
+		if math.Abs(normal[i]) > 0.5 {
+			if normal[i] > 0 {
+				face = normalFaces[i][0]
+			} else {
+				face = normalFaces[i][1]
+			}
+		}
+	}
+
+	color := model.Color(paintColor)
+	if paintColor == cycleColors {
+		color = (cube.Cubies[pos.X][pos.Y][pos.Z].Colors[face] + 1) % 6
+	}
+
+	if err := cube.SetSticker(pos, face, color); err != nil {
+		println("Error painting sticker:", err.Error())
+		return
+	}
+	println("Painted sticker at", pos.X, pos.Y, pos.Z, "face", face, "with color", color)
+
+	createCube()
+	notifyEdit()
+}
+
+// notifyEdit passes the validation of the edited cube to the page's onWasmCubeEdited hook, if any
+func notifyEdit() {
+	hook := js.Global().Get("onWasmCubeEdited")
+	if hook.Type() != js.TypeFunction {
+		return
+	}
+	hook.Invoke(validationJSON())
+}
+
+// validationJSON validates the displayed cube and returns the result as JSON
+func validationJSON() string {
+	result := validationResult{Valid: true}
+	if err := cube.Validate(); err != nil {
+		result.Valid = false
+		if validationErr, ok := err.(*model.ValidationError); ok {
+			result.Problems = validationErr.Problems
+		} else {
+			result.Problems = []string{err.Error()}
+		}
+	}
+	data, _ := json.Marshal(result)
+	return string(data)
+}
+
+// Enter or leave edit mode, e.g. wasmSetEditMode(true).
+// Layer turns by drag or keyboard are disabled while editing.
+func setEditMode(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeBoolean {
+		return js.ValueOf("Invalid arguments: expected a boolean")
+	}
+	if args[0].Bool() && (isAnimating || len(actionQueue) > 0) {
+		return js.ValueOf("Cannot edit while turns are playing")
+	}
+	if args[0].Bool() && replayActive {
+		return js.ValueOf("Cannot edit while replaying")
+	}
+
+	editMode = args[0].Bool()
+	if drag.active {
+		endDrag()
+	}
+	println("Edit mode:", editMode)
+	return js.ValueOf(editMode)
+}
+
+// Choose the color painted by clicks, from 0 to 5 in the model's Color order,
+// or -1 to cycle each clicked sticker through the colors
+func setPaintColor(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeNumber {
+		return js.ValueOf("Invalid arguments: expected a color number")
+	}
+	color := args[0].Int()
+	if color != cycleColors && (color < int(model.White) || color > int(model.Green)) {
+		return js.ValueOf("Invalid color: must be between 0 and 5, or -1 to cycle")
+	}
+	paintColor = color
+	return js.ValueOf(paintColor)
+}
+
+// Validate the displayed cube, returning {valid, problems} as JSON
+func validateCube(this js.Value, args []js.Value) any {
+	return js.ValueOf(validationJSON())
+}
+
+// Send the edited cube to the server, which loads it into the shared cube.
+// Returns the fetch promise; the server answers 400 with the problems if the cube is invalid.
+func sendStateToServer(this js.Value, args []js.Value) any {
+	body, err := json.Marshal(struct {
+		State [3][3][3]*model.Cubie `json:"state"`
+	}{cube.Cubies})
+	if err != nil {
+		println("Error encoding state:", err.Error())
+		return js.Global().Get("Promise").Call("reject", err.Error())
+	}
+
+	return js.Global().Call("fetch", "/api/state", map[string]any{
+		"method":  "POST",
+		"headers": map[string]any{"Content-Type": "application/json"},
+		"body":    string(body),
+	})
+}
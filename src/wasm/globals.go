@@ -4,7 +4,9 @@
 package main
 
 import (
+	"fmt"
 	"kikokai/src/model"
+	"math/rand"
 	"syscall/js"
 )
 
@@ -14,6 +16,7 @@ var (
 	scene       js.Value
 	renderer    js.Value
 	camera      js.Value
+	controls    js.Value
 	cubeGroup   js.Value
 	isAnimating bool
 
@@ -21,16 +24,6 @@ var (
 	cubeSize float64 = 1
 	gap      float64 = 0.05
 
-	// Color mapping from Color enum to hex values
-	colorMap = map[model.Color]uint32{
-		model.White:  0xFFFFFF,
-		model.Yellow: 0xFFFF00,
-		model.Blue:   0x0000FF,
-		model.Green:  0x00FF00,
-		model.Red:    0xFF0000,
-		model.Orange: 0xFFA500,
-	}
-
 	// THREE.js references
 	three      js.Value
 	vector3    js.Value
@@ -41,11 +34,15 @@ var (
 
 	// Functions to prevent garbage collection
 	funcs []js.Func
+
+	// Identifies the moves this client sent to the server
+	clientID string
 )
 
 func init() {
 	// Initialize a new cube
 	cube = model.NewCube()
+	clientID = fmt.Sprintf("wasm-%08x", rand.Uint32())
 }
 
 // Set up Three.js references
@@ -0,0 +1,120 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"kikokai/src/solver"
+	"math"
+	"syscall/js"
+)
+
+// Appearance of the hint arrow, drawn around the face to turn just outside the cube
+const (
+	hintColor    = 0xff00ff
+	hintRadius   = 1.1
+	hintDistance = 1.7 // from the center of the cube
+	hintArc      = 1.5 * math.Pi
+)
+
+// Outward normal of each face, in Three.js coordinates
+var faceNormals = map[model.FaceIndex][3]float64{
+	model.Right: {1, 0, 0},
+	model.Left:  {-1, 0, 0},
+	model.Up:    {0, 1, 0},
+	model.Down:  {0, -1, 0},
+	model.Front: {0, 0, 1},
+	model.Back:  {0, 0, -1},
+}
+
+// Arrow currently shown, undefined when there is none
+var hintArrow js.Value
+
+// Show an arrow on the layer to turn next. With a move in notation, e.g. wasmShowHint("R2")
+// as returned by /api/hint, that move is shown; without argument the displayed cube is solved
+// locally and the hint is returned as JSON: {solved, move, moves, distance, solution}.
+// The arrow disappears at the next change of the cube.
+func showHint(this js.Value, args []js.Value) any {
+	var hint solver.Hint
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		moves, err := model.ParseAlgorithm(args[0].String())
+		// A half turn is two identical quarter turns, anything else is more than one face turn
+		if err != nil || len(moves) == 0 || len(moves) > 2 || len(moves) == 2 && moves[0] != moves[1] {
+			return js.ValueOf("Invalid move: expected a single face turn such as R, U' or F2")
+		}
+		hint = solver.Hint{Move: args[0].String(), Moves: moves}
+	} else {
+		println("Solving the displayed cube for a hint")
+		var err error
+		if hint, err = solver.NextMove(cube); err != nil {
+			println("Error computing hint:", err.Error())
+			return js.ValueOf("Cannot compute a hint: " + err.Error())
+		}
+	}
+
+	clearHint()
+	if !hint.Solved {
+		move := hint.Moves[0]
+		drawHintArrow(moveToFace(move), move.Direction == 1, len(hint.Moves) == 2)
+	}
+
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		return js.ValueOf("Hint shown")
+	}
+	data, _ := json.Marshal(hint)
+	return js.ValueOf(string(data))
+}
+
+// drawHintArrow draws a curved arrow in front of a face, turning clockwise as seen from
+// that face or counter-clockwise; half turns get a second arrowhead
+func drawHintArrow(face model.FaceIndex, clockwise, half bool) {
+	material := three.Get("MeshBasicMaterial").New(map[string]any{
+		"color": hintColor,
+		"side":  three.Get("DoubleSide"),
+	})
+
+	// The arrow is built in the XY plane turning counter-clockwise seen from +Z,
+	// then mirrored for clockwise turns and turned so that +Z points out of the face
+	arrow := group.New()
+	arc := mesh.New(three.Get("TorusGeometry").New(hintRadius, 0.06, 8, 48, hintArc), material)
+	arrow.Call("add", arc)
+
+	heads := []float64{hintArc}
+	if half {
+		heads = append(heads, hintArc-0.6)
+	}
+	for _, angle := range heads {
+		head := mesh.New(three.Get("ConeGeometry").New(0.18, 0.4, 16), material)
+		head.Get("position").Call("set", hintRadius*math.Cos(angle), hintRadius*math.Sin(angle), 0)
+		// Cones point along +Y, turn them along the arc
+		head.Get("rotation").Set("z", angle)
+		arrow.Call("add", head)
+	}
+	if clockwise {
+		arrow.Get("scale").Set("y", -1)
+	}
+
+	hintArrow = group.New()
+	hintArrow.Call("add", arrow)
+	normal := faceNormals[face]
+	hintArrow.Get("position").Call("set", normal[0]*hintDistance, normal[1]*hintDistance, normal[2]*hintDistance)
+	hintArrow.Call("lookAt", normal[0]*2*hintDistance, normal[1]*2*hintDistance, normal[2]*2*hintDistance)
+	scene.Call("add", hintArrow)
+}
+
+// clearHint removes the hint arrow, if any
+func clearHint() {
+	if hintArrow.IsUndefined() {
+		return
+	}
+	scene.Call("remove", hintArrow)
+	hintArrow = js.Undefined()
+}
+
+// Remove the hint arrow
+func hideHint(this js.Value, args []js.Value) any {
+	clearHint()
+	return js.ValueOf("Hint hidden")
+}
@@ -0,0 +1,313 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"math"
+	"syscall/js"
+)
+
+// Minimum pointer travel in pixels before a drag turns a layer
+const dragThreshold = 10
+
+// dragState follows a pointer that started on a sticker
+type dragState struct {
+	active    bool
+	pointerID int
+	startX    float64
+	startY    float64
+	piece     js.Value   // mesh under the pointer
+	normal    [3]float64 // outward normal of the sticker, in Three.js coordinates
+	point     [3]float64 // point of the sticker under the pointer
+}
+
+var (
+	raycaster js.Value
+	drag      dragState
+)
+
+// Set up pointer listeners (mouse and touch) turning the layer dragged across
+func setupDragToTurn(container js.Value) {
+	raycaster = three.Get("Raycaster").New()
+
+	// Listen on the container during the capture phase, so that a drag starting on
+	// a sticker is seen before OrbitControls and does not orbit the camera
+	options := map[string]any{"capture": true}
+	pointerDown := js.FuncOf(onPointerDown)
+	pointerMove := js.FuncOf(onPointerMove)
+	pointerUp := js.FuncOf(onPointerUp)
+	container.Call("addEventListener", "pointerdown", pointerDown, options)
+	container.Call("addEventListener", "pointermove", pointerMove, options)
+	container.Call("addEventListener", "pointerup", pointerUp, options)
+	container.Call("addEventListener", "pointercancel", pointerUp, options)
+
+	funcs = append(funcs, pointerDown, pointerMove, pointerUp)
+}
+
+// Start a drag when the pointer goes down on a sticker
+func onPointerDown(this js.Value, args []js.Value) any {
+	event := args[0]
+	if drag.active || (event.Get("button").Type() == js.TypeNumber && event.Get("button").Int() != 0) {
+		return nil
+	}
+
+	hit, ok := pickSticker(event.Get("clientX").Float(), event.Get("clientY").Float())
+	if !ok {
+		// Not on a sticker, let OrbitControls orbit the camera
+		return nil
+	}
+
+	if editMode {
+		// Paint the sticker instead of turning its layer
+		paintSticker(hit)
+		event.Call("stopPropagation")
+		return nil
+	}
+
+	if replayActive {
+		// The replay drives the cube, let OrbitControls orbit the camera
+		return nil
+	}
+
+	drag = dragState{
+		active:    true,
+		pointerID: event.Get("pointerId").Int(),
+		startX:    event.Get("clientX").Float(),
+		startY:    event.Get("clientY").Float(),
+		piece:     hit.Get("object"),
+		normal:    vectorToArray(hit.Get("face").Get("normal")),
+		point:     vectorToArray(hit.Get("point")),
+	}
+
+	// Keep the camera still while turning
+	controls.Set("enabled", false)
+	event.Call("stopPropagation")
+	renderer.Get("domElement").Call("setPointerCapture", drag.pointerID)
+	return nil
+}
+
+// Turn the layer once the pointer has travelled far enough
+func onPointerMove(this js.Value, args []js.Value) any {
+	event := args[0]
+	if !drag.active || event.Get("pointerId").Int() != drag.pointerID {
+		return nil
+	}
+	event.Call("stopPropagation")
+
+	dx := event.Get("clientX").Float() - drag.startX
+	dy := event.Get("clientY").Float() - drag.startY
+	if math.Hypot(dx, dy) < dragThreshold {
+		return nil
+	}
+
+	// One turn per drag
+	move, ok := dragToMove(dx, dy)
+	endDrag()
+	if !ok {
+		return nil
+	}
+
+	println("Drag turns layer:", move.Axis, move.Layer, move.Direction)
+	applyAndPostMove(move)
+	return nil
+}
+
+// Stop following the pointer
+func onPointerUp(this js.Value, args []js.Value) any {
+	event := args[0]
+	if drag.active && event.Get("pointerId").Int() == drag.pointerID {
+		endDrag()
+	}
+	return nil
+}
+
+func endDrag() {
+	if drag.active {
+		renderer.Get("domElement").Call("releasePointerCapture", drag.pointerID)
+	}
+	drag = dragState{}
+	controls.Set("enabled", true)
+}
+
+// pickSticker returns the closest intersection with an outer face of a piece
+func pickSticker(clientX, clientY float64) (js.Value, bool) {
+	rect := renderer.Get("domElement").Call("getBoundingClientRect")
+	pointer := three.Get("Vector2").New(
+		(clientX-rect.Get("left").Float())/rect.Get("width").Float()*2-1,
+		-(clientY-rect.Get("top").Float())/rect.Get("height").Float()*2+1,
+	)
+	raycaster.Call("setFromCamera", pointer, camera)
+
+	intersections := raycaster.Call("intersectObjects", cubeGroup.Get("children"), false)
+	if intersections.Length() == 0 {
+		return js.Undefined(), false
+	}
+
+	// Only the first intersection is visible, it must be a sticker
+	hit := intersections.Index(0)
+	userData := hit.Get("object").Get("userData")
+	if userData.Get("posX").IsUndefined() || hit.Get("face").IsNull() {
+		return js.Undefined(), false
+	}
+	normal := vectorToArray(hit.Get("face").Get("normal"))
+	position := [3]float64{userData.Get("posX").Float(), userData.Get("posY").Float(), userData.Get("posZ").Float()}
+	for i := range 3 {
+		if math.Abs(normal[i]) > 0.5 && math.Round(normal[i]) == position[i] {
+			return hit, true
+		}
+	}
+	return js.Undefined(), false
+}
+
+// dragToMove converts a drag on the screen into the layer turn moving the sticker that way
+func dragToMove(dx, dy float64) (model.Move, bool) {
+	// Pick the direction along the sticker's face whose projection on screen best follows the drag
+	var best [3]float64
+	bestScore := math.Inf(-1)
+	for i := range 3 {
+		if math.Abs(drag.normal[i]) > 0.5 {
+			continue
+		}
+		for _, sign := range []float64{1, -1} {
+			var direction [3]float64
+			direction[i] = sign
+			sx, sy := projectDirection(drag.point, direction)
+			length := math.Hypot(sx, sy)
+			if length == 0 {
+				continue
+			}
+			if score := (sx*dx + sy*dy) / length; score > bestScore {
+				bestScore = score
+				best = direction
+			}
+		}
+	}
+
+	// A positive rotation around normal × direction moves the sticker along direction
+	axis := cross(drag.normal, best)
+	userData := drag.piece.Get("userData")
+	position := [3]int{userData.Get("posX").Int(), userData.Get("posY").Int(), userData.Get("posZ").Int()}
+
+	for i := range 3 {
+		if math.Abs(axis[i]) < 0.5 {
+			continue
+		}
+		layer := position[i]
+		if layer == 0 {
+			println("Middle layers cannot be turned")
+			return model.Move{}, false
+		}
+
+		// Three.js x, y, z map to the model's z, y, x axes
+		modelAxis := [3]string{"z", "y", "x"}[i]
+		// Clockwise seen from a face is a negative rotation around its outward axis
+		angleSign := int(math.Copysign(1, axis[i]))
+		return model.Move{Axis: modelAxis, Layer: layer, Direction: -angleSign * layer}, true
+	}
+	return model.Move{}, false
+}
+
+// projectDirection returns the on-screen vector, in pixels, of a unit step from a point
+func projectDirection(point, direction [3]float64) (float64, float64) {
+	from := vector3.New(point[0], point[1], point[2]).Call("project", camera)
+	to := vector3.New(point[0]+direction[0], point[1]+direction[1], point[2]+direction[2]).Call("project", camera)
+
+	rect := renderer.Get("domElement").Call("getBoundingClientRect")
+	// Screen y grows downwards while normalized device coordinates grow upwards
+	return (to.Get("x").Float() - from.Get("x").Float()) * rect.Get("width").Float() / 2,
+		-(to.Get("y").Float() - from.Get("y").Float()) * rect.Get("height").Float() / 2
+}
+
+// applyAndPostMove animates the move locally right away and sends it to the server,
+// tagged with this client's id so that the broadcast echo is not animated twice
+func applyAndPostMove(move model.Move) {
+	enqueueTurn(moveToFace(move), model.TurningDirection(move.Direction == 1))
+
+	body, err := json.Marshal(struct {
+		model.Move
+		Source string `json:"source"`
+	}{move, clientID})
+	if err != nil {
+		println("Error encoding move:", err.Error())
+		return
+	}
+
+	// The turn is already shown, so show the server's cube again if it rejects the move or cannot be reached
+	request := js.Global().Call("fetch", "/api/rotate-axis", map[string]any{
+		"method":  "POST",
+		"headers": map[string]any{"Content-Type": "application/json"},
+		"body":    string(body),
+	})
+	then(request, func(response js.Value) {
+		if !response.Get("ok").Bool() {
+			println("Move rejected by the server with status", response.Get("status").Int())
+			resyncState()
+		}
+	}, func(reason js.Value) {
+		println("Error sending move:", reason.Call("toString").String())
+		resyncState()
+	})
+}
+
+// resyncState replaces the displayed cube with the server's once the queued turns have been played
+func resyncState() {
+	logError := func(reason js.Value) {
+		println("Error fetching the cube state:", reason.Call("toString").String())
+	}
+	then(js.Global().Call("fetch", "/api/state"), func(response js.Value) {
+		if !response.Get("ok").Bool() {
+			println("Error fetching the cube state: status", response.Get("status").Int())
+			return
+		}
+		then(response.Call("text"), func(text js.Value) {
+			var state struct {
+				State [3][3][3]*model.Cubie `json:"state"`
+			}
+			if err := json.Unmarshal([]byte(text.String()), &state); err != nil {
+				println("Error parsing cube state:", err.Error())
+				return
+			}
+			enqueueUpdate(func() {
+				cube.Cubies = state.State
+				resetTrackPath()
+				createCube()
+			})
+		}, logError)
+	}, logError)
+}
+
+// then calls onFulfilled or onRejected with the value of the promise once it settles
+func then(promise js.Value, onFulfilled, onRejected func(js.Value)) {
+	var fulfilled, rejected js.Func
+	settle := func(handle func(js.Value)) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) any {
+			// Only one of the two is ever called
+			fulfilled.Release()
+			rejected.Release()
+			handle(args[0])
+			return nil
+		})
+	}
+	fulfilled, rejected = settle(onFulfilled), settle(onRejected)
+	promise.Call("then", fulfilled, rejected)
+}
+
+// Get the id tagging the moves sent by this client
+func getClientID(this js.Value, args []js.Value) any {
+	return js.ValueOf(clientID)
+}
+
+func vectorToArray(v js.Value) [3]float64 {
+	return [3]float64{v.Get("x").Float(), v.Get("y").Float(), v.Get("z").Float()}
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
@@ -0,0 +1,126 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"strings"
+	"syscall/js"
+)
+
+// defaultKeyMap is the face-turn subset of the cstimer/qqtimer key map.
+// Wide turns, slices and rotations of that map are not available since only outer layers turn.
+var defaultKeyMap = map[string]string{
+	"i": "R", "k": "R'",
+	"d": "L", "e": "L'",
+	"j": "U", "f": "U'",
+	"s": "D", "l": "D'",
+	"h": "F", "g": "F'",
+	"w": "B", "o": "B'",
+}
+
+var (
+	// Maps a key (lower case) to the moves it triggers
+	keyMap = parseKeyMap(defaultKeyMap)
+
+	keyboardEnabled = true
+)
+
+// parseKeyMap converts a key map in notation to moves, skipping invalid entries
+func parseKeyMap(notations map[string]string) map[string][]model.Move {
+	keys := make(map[string][]model.Move, len(notations))
+	for key, notation := range notations {
+		moves, err := model.ParseAlgorithm(notation)
+		if err != nil {
+			println("Ignoring key", key, ":", err.Error())
+			continue
+		}
+		keys[strings.ToLower(key)] = moves
+	}
+	return keys
+}
+
+// Set up the keyboard listener turning layers
+func setupKeyboard() {
+	keyDown := js.FuncOf(onKeyDown)
+	js.Global().Get("document").Call("addEventListener", "keydown", keyDown)
+	funcs = append(funcs, keyDown)
+}
+
+// Turn the layer mapped to the pressed key
+func onKeyDown(this js.Value, args []js.Value) any {
+	event := args[0]
+	if !keyboardEnabled || editMode || replayActive || event.Get("repeat").Bool() ||
+		event.Get("ctrlKey").Bool() || event.Get("metaKey").Bool() || event.Get("altKey").Bool() {
+		return nil
+	}
+
+	// Leave the keys to form fields
+	target := event.Get("target")
+	if !target.IsUndefined() && !target.IsNull() {
+		tag := strings.ToLower(target.Get("tagName").String())
+		if tag == "input" || tag == "textarea" || tag == "select" || target.Get("isContentEditable").Truthy() {
+			return nil
+		}
+	}
+
+	moves, ok := keyMap[strings.ToLower(event.Get("key").String())]
+	if !ok {
+		return nil
+	}
+	event.Call("preventDefault")
+
+	for _, move := range moves {
+		println("Key", event.Get("key").String(), "turns", move.String())
+		applyAndPostMove(move)
+	}
+	return nil
+}
+
+// Replace the key map from JavaScript, e.g. wasmSetKeyMap({i: "R", k: "R'"}).
+// Without argument the default cstimer map is restored.
+func setKeyMap(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].IsUndefined() || args[0].IsNull() {
+		keyMap = parseKeyMap(defaultKeyMap)
+		return js.ValueOf("Default key map restored")
+	}
+	if args[0].Type() != js.TypeObject {
+		return js.ValueOf("Invalid arguments: expected an object mapping keys to moves")
+	}
+
+	notations := make(map[string]string)
+	keys := js.Global().Get("Object").Call("keys", args[0])
+	for i := 0; i < keys.Length(); i++ {
+		key := keys.Index(i).String()
+		notation := args[0].Get(key).String()
+		if _, err := model.ParseAlgorithm(notation); err != nil {
+			println("Error: Invalid moves for key", key, ":", err.Error())
+			return js.ValueOf("Invalid moves for key " + key)
+		}
+		notations[key] = notation
+	}
+
+	keyMap = parseKeyMap(notations)
+	return js.ValueOf("Key map updated")
+}
+
+// Get the current key map as a JSON object mapping keys to moves in notation
+func getKeyMap(this js.Value, args []js.Value) any {
+	notations := make(map[string]string, len(keyMap))
+	for key, moves := range keyMap {
+		notations[key] = model.FormatAlgorithm(moves)
+	}
+	data, _ := json.Marshal(notations)
+	return js.ValueOf(string(data))
+}
+
+// Enable or disable keyboard turning, e.g. while typing elsewhere on the page
+func setKeyboardEnabled(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeBoolean {
+		return js.ValueOf("Invalid arguments: expected a boolean")
+	}
+	keyboardEnabled = args[0].Bool()
+	return js.ValueOf(keyboardEnabled)
+}
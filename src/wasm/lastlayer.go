@@ -0,0 +1,21 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// Recognize the OLL or PLL case of the displayed cube, for practising the last layer.
+// Returns the case as JSON: {step, case, auf, algorithm, solution}, or an error message
+// when the first two layers are not solved.
+func lastLayerCase(this js.Value, args []js.Value) any {
+	found, err := cube.RecognizeLastLayer()
+	if err != nil {
+		return js.ValueOf("No last layer case: " + err.Error())
+	}
+	data, _ := json.Marshal(found)
+	return js.ValueOf(string(data))
+}
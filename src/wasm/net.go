@@ -0,0 +1,100 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"fmt"
+	"kikokai/src/model"
+	"syscall/js"
+)
+
+// Canvas showing the unfolded cube, undefined when the page has none
+var netCanvas js.Value
+
+// Letters written on the center sticker of each face of the net
+var netFaceLetters = map[model.FaceIndex]string{
+	model.Front: "F",
+	model.Right: "R",
+	model.Back:  "B",
+	model.Left:  "L",
+	model.Up:    "U",
+	model.Down:  "D",
+}
+
+// setupNet finds the canvas of the net view and redraws it when its size changes
+func setupNet() {
+	netCanvas = js.Global().Get("document").Call("getElementById", "cubeNet")
+	if netCanvas.IsNull() || netCanvas.IsUndefined() {
+		println("No cubeNet canvas, the net view is disabled")
+		netCanvas = js.Undefined()
+		return
+	}
+
+	if resizeObserver := js.Global().Get("ResizeObserver"); !resizeObserver.IsUndefined() {
+		onResize := js.FuncOf(func(this js.Value, args []js.Value) any {
+			drawNet()
+			return nil
+		})
+		funcs = append(funcs, onResize)
+		resizeObserver.New(onResize).Call("observe", netCanvas)
+	}
+}
+
+// drawNet draws the 54 stickers of the cube unfolded into a cross:
+// Up on top, Left, Front, Right and Back in the middle, Down below
+func drawNet() {
+	if netCanvas.IsUndefined() {
+		return
+	}
+
+	// The net is 12 stickers wide and 9 high; draw at the screen's pixel density
+	width := netCanvas.Get("clientWidth").Float()
+	if width <= 0 {
+		return
+	}
+	ratio := js.Global().Get("devicePixelRatio").Float()
+	sticker := width * ratio / 12
+	netCanvas.Set("width", sticker*12)
+	netCanvas.Set("height", sticker*9)
+
+	ctx := netCanvas.Call("getContext", "2d")
+	ctx.Call("clearRect", 0, 0, sticker*12, sticker*9)
+
+	gap := sticker * 0.06
+	scheme := model.CurrentColorScheme()
+	for _, placement := range model.NetLayout {
+		colors := cube.Face(placement.Face)
+		for row := range 3 {
+			for col := range 3 {
+				x := float64(placement.Col*3+col) * sticker
+				y := float64(placement.Row*3+row) * sticker
+				ctx.Set("fillStyle", "#111111")
+				ctx.Call("fillRect", x, y, sticker, sticker)
+				color := scheme.Color(colors[row][col])
+				ctx.Set("fillStyle", color.Hex)
+				ctx.Call("fillRect", x+gap, y+gap, sticker-2*gap, sticker-2*gap)
+
+				if scheme.Letters && (row != 1 || col != 1) {
+					ctx.Set("fillStyle", color.LetterHex())
+					ctx.Set("font", fmt.Sprintf("bold %.0fpx sans-serif", sticker*0.45))
+					ctx.Set("textAlign", "center")
+					ctx.Set("textBaseline", "middle")
+					ctx.Call("fillText", color.Letter, x+sticker/2, y+sticker/2)
+				}
+			}
+		}
+
+		// Name the face on its center sticker, after its color's letter when the scheme shows letters
+		faceLabel := netFaceLetters[placement.Face]
+		if scheme.Letters {
+			faceLabel = scheme.Color(colors[1][1]).Letter + "/" + faceLabel
+		}
+		ctx.Set("fillStyle", "rgba(0, 0, 0, 0.5)")
+		ctx.Set("font", fmt.Sprintf("bold %.0fpx sans-serif", sticker*0.4))
+		ctx.Set("textAlign", "center")
+		ctx.Set("textBaseline", "middle")
+		ctx.Call("fillText", faceLabel,
+			(float64(placement.Col*3)+1.5)*sticker, (float64(placement.Row*3)+1.5)*sticker)
+	}
+}
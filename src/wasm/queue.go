@@ -0,0 +1,108 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"kikokai/src/model"
+	"syscall/js"
+)
+
+// queuedAction is a change to the cube waiting for the running animation to finish
+type queuedAction struct {
+	face      model.FaceIndex
+	clockwise model.TurningDirection
+	update    func() // non-animated change (state update, reset...) applied in order with the turns
+}
+
+var (
+	// Pending changes, played one after the other
+	actionQueue []queuedAction
+
+	// Speed multiplier applied to the turn animations
+	queueSpeed float64 = 1
+
+	// When catch-up is enabled, turns are applied without animation
+	// as long as more than catchUpThreshold actions are waiting
+	catchUp          bool
+	catchUpThreshold = 5
+)
+
+// enqueueTurn adds a turn to the queue and starts playing it if nothing is animating
+func enqueueTurn(face model.FaceIndex, clockwise model.TurningDirection) {
+	actionQueue = append(actionQueue, queuedAction{face: face, clockwise: clockwise})
+	processQueue()
+}
+
+// enqueueUpdate adds a non-animated change to the queue, so that it is applied
+// after the turns received before it
+func enqueueUpdate(update func()) {
+	actionQueue = append(actionQueue, queuedAction{update: update})
+	processQueue()
+}
+
+// processQueue plays the pending actions until an animation starts or the queue is empty.
+// It is called again when the running animation completes.
+func processQueue() {
+	redraw := false
+	for len(actionQueue) > 0 && !isAnimating {
+		action := actionQueue[0]
+		actionQueue = actionQueue[1:]
+
+		if action.update != nil {
+			action.update()
+			redraw = false // updates rebuild the cube themselves
+			continue
+		}
+
+		// Skip the animation while the queue is too long to keep up
+		if catchUp && len(actionQueue) >= catchUpThreshold {
+			cube.RotateAxis(model.FaceToCoordinate(action.face), action.clockwise)
+			recordTurn()
+			redraw = true
+			continue
+		}
+
+		if redraw {
+			createCube()
+			redraw = false
+		}
+		isAnimating = true
+		animateFaceRotation(action.face, action.clockwise)
+	}
+	if redraw {
+		createCube()
+	}
+}
+
+// Configure the animation queue from JavaScript, e.g. wasmSetQueueOptions({speed: 2, catchUp: true, threshold: 5})
+func setQueueOptions(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeObject {
+		return js.ValueOf("Invalid arguments: expected an options object")
+	}
+	options := args[0]
+
+	if speed := options.Get("speed"); speed.Type() == js.TypeNumber {
+		if speed.Float() <= 0 {
+			return js.ValueOf("Invalid speed: must be positive")
+		}
+		queueSpeed = speed.Float()
+	}
+	if enabled := options.Get("catchUp"); enabled.Type() == js.TypeBoolean {
+		catchUp = enabled.Bool()
+	}
+	if threshold := options.Get("threshold"); threshold.Type() == js.TypeNumber {
+		if threshold.Int() < 1 {
+			return js.ValueOf("Invalid threshold: must be at least 1")
+		}
+		catchUpThreshold = threshold.Int()
+	}
+
+	println("Queue options: speed", queueSpeed, "catch-up", catchUp, "threshold", catchUpThreshold)
+	return js.ValueOf("Queue options updated")
+}
+
+// Get the number of actions waiting in the queue
+func getQueueLength(this js.Value, args []js.Value) any {
+	return js.ValueOf(len(actionQueue))
+}
@@ -0,0 +1,243 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"syscall/js"
+)
+
+// How often, in milliseconds, a playing replay checks whether the next move can start
+const replayTickInterval = 50
+
+var (
+	// While a replay is loaded the cube shows it instead of the shared cube,
+	// and layers cannot be turned by drag or keyboard
+	replayActive bool
+
+	replayStart    *model.Cube
+	replayMoves    []model.Move
+	replayPosition int // number of moves applied to replayStart
+	replayPlaying  bool
+
+	replayTimer js.Value // interval id while playing
+	replayTick  js.Func
+)
+
+// replayStatus is the state of the replay player, sent to JavaScript
+type replayStatus struct {
+	Active   bool     `json:"active"`
+	Playing  bool     `json:"playing"`
+	Position int      `json:"position"`
+	Length   int      `json:"length"`
+	Moves    []string `json:"moves"`
+}
+
+// Load a replay and show its starting state, e.g.
+// wasmReplayLoad({scramble: "R U F'", algorithm: "F U' R'"}) or wasmReplayLoad({state: "[...]", algorithm: "R U"}).
+// The start is the given state (solved by default) with the scramble applied; the algorithm is then played.
+func loadReplay(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeObject {
+		return js.ValueOf("Invalid arguments: expected an options object")
+	}
+	if editMode {
+		return js.ValueOf("Cannot replay while editing")
+	}
+	options := args[0]
+
+	start := model.NewCube()
+	if state := options.Get("state"); state.Type() == js.TypeString {
+		var cubies [3][3][3]*model.Cubie
+		if err := json.Unmarshal([]byte(state.String()), &cubies); err != nil {
+			println("Error parsing replay state:", err.Error())
+			return js.ValueOf("Invalid state format")
+		}
+		var err error
+		if start, err = model.CubeFromState(cubies); err != nil {
+			return js.ValueOf("Invalid state: " + err.Error())
+		}
+	}
+
+	if scramble := options.Get("scramble"); scramble.Type() == js.TypeString {
+		moves, err := model.ParseAlgorithm(scramble.String())
+		if err != nil {
+			return js.ValueOf("Invalid scramble: " + err.Error())
+		}
+		start.ApplyMoves(moves)
+	}
+
+	var moves []model.Move
+	if algorithm := options.Get("algorithm"); algorithm.Type() == js.TypeString {
+		var err error
+		if moves, err = model.ParseAlgorithm(algorithm.String()); err != nil {
+			return js.ValueOf("Invalid algorithm: " + err.Error())
+		}
+	}
+
+	pauseReplay()
+	replayActive = true
+	replayStart = start
+	replayMoves = moves
+	seekReplay(0)
+
+	println("Replay loaded:", len(moves), "moves")
+	return js.ValueOf("Replay loaded")
+}
+
+// seekReplay shows the state after the given number of moves, without animation
+func seekReplay(position int) {
+	position = max(0, min(position, len(replayMoves)))
+	replayPosition = position
+
+	// Drop the turns still waiting, the state is rebuilt from the start
+	actionQueue = nil
+	enqueueUpdate(func() {
+		cube = replayStart.Clone()
+		cube.ApplyMoves(replayMoves[:position])
+		resetTrackPath()
+		createCube()
+	})
+	notifyReplay()
+}
+
+// stepReplay animates one move forward or backward, returning false at either end
+func stepReplay(forward bool) bool {
+	if forward {
+		if replayPosition >= len(replayMoves) {
+			return false
+		}
+		move := replayMoves[replayPosition]
+		replayPosition++
+		enqueueTurn(moveToFace(move), model.TurningDirection(move.Direction == 1))
+	} else {
+		if replayPosition <= 0 {
+			return false
+		}
+		replayPosition--
+		move := replayMoves[replayPosition].Inverse()
+		enqueueTurn(moveToFace(move), model.TurningDirection(move.Direction == 1))
+	}
+	notifyReplay()
+	return true
+}
+
+// onReplayTick starts the next move once the previous one has finished animating
+func onReplayTick(this js.Value, args []js.Value) any {
+	if !replayPlaying || isAnimating || len(actionQueue) > 0 {
+		return nil
+	}
+	if !stepReplay(true) {
+		pauseReplay()
+		notifyReplay()
+	}
+	return nil
+}
+
+func pauseReplay() {
+	if replayPlaying {
+		js.Global().Call("clearInterval", replayTimer)
+	}
+	replayPlaying = false
+}
+
+// notifyReplay passes the player's status to the page's onWasmReplayUpdate hook, if any
+func notifyReplay() {
+	hook := js.Global().Get("onWasmReplayUpdate")
+	if hook.Type() != js.TypeFunction {
+		return
+	}
+	hook.Invoke(replayStatusJSON())
+}
+
+func replayStatusJSON() string {
+	status := replayStatus{
+		Active:   replayActive,
+		Playing:  replayPlaying,
+		Position: replayPosition,
+		Length:   len(replayMoves),
+		Moves:    make([]string, len(replayMoves)),
+	}
+	for i, move := range replayMoves {
+		status.Moves[i] = move.String()
+	}
+	data, _ := json.Marshal(status)
+	return string(data)
+}
+
+// Play the replay from the current position; the speed follows wasmSetQueueOptions
+func playReplay(this js.Value, args []js.Value) any {
+	if !replayActive {
+		return js.ValueOf("No replay loaded")
+	}
+	if replayPlaying {
+		return js.ValueOf("Replay already playing")
+	}
+
+	// Playing from the end starts over
+	if replayPosition >= len(replayMoves) {
+		seekReplay(0)
+	}
+
+	if replayTick.IsUndefined() {
+		replayTick = js.FuncOf(onReplayTick)
+		funcs = append(funcs, replayTick)
+	}
+	replayPlaying = true
+	replayTimer = js.Global().Call("setInterval", replayTick, replayTickInterval)
+	notifyReplay()
+	return js.ValueOf("Replay playing")
+}
+
+// Pause the replay after the move being animated
+func pauseReplayCallback(this js.Value, args []js.Value) any {
+	pauseReplay()
+	notifyReplay()
+	return js.ValueOf("Replay paused")
+}
+
+// Step the replay by one move, wasmReplayStep(1) forward or wasmReplayStep(-1) backward
+func stepReplayCallback(this js.Value, args []js.Value) any {
+	if !replayActive {
+		return js.ValueOf("No replay loaded")
+	}
+	if len(args) < 1 || args[0].Type() != js.TypeNumber || (args[0].Int() != 1 && args[0].Int() != -1) {
+		return js.ValueOf("Invalid arguments: expected 1 or -1")
+	}
+	pauseReplay()
+	if !stepReplay(args[0].Int() == 1) {
+		notifyReplay()
+		return js.ValueOf("No more moves")
+	}
+	return js.ValueOf(replayPosition)
+}
+
+// Jump to the state after the given number of moves, e.g. from the scrubber
+func seekReplayCallback(this js.Value, args []js.Value) any {
+	if !replayActive {
+		return js.ValueOf("No replay loaded")
+	}
+	if len(args) < 1 || args[0].Type() != js.TypeNumber {
+		return js.ValueOf("Invalid arguments: expected a position")
+	}
+	pauseReplay()
+	seekReplay(args[0].Int())
+	return js.ValueOf(replayPosition)
+}
+
+// Get the player's status as JSON: {active, playing, position, length, moves}
+func getReplayStatus(this js.Value, args []js.Value) any {
+	return js.ValueOf(replayStatusJSON())
+}
+
+// Leave the replay; the page then shows the shared cube again
+func closeReplay(this js.Value, args []js.Value) any {
+	pauseReplay()
+	replayActive = false
+	replayStart = nil
+	replayMoves = nil
+	replayPosition = 0
+	notifyReplay()
+	return js.ValueOf("Replay closed")
+}
@@ -16,28 +16,31 @@ func initThreeScene(this js.Value, args []js.Value) any {
 	scene = three.Get("Scene").New()
 	scene.Call("add", setupLighting())
 
-	// Create camera
-	camera = three.Get("PerspectiveCamera").New(75, 600/500, 0.1, 1000)
-	camera.Get("position").Set("x", 4)
-	camera.Get("position").Set("y", 4)
-	camera.Get("position").Set("z", 4)
-	camera.Call("lookAt", 0, 0, 0)
+	// Create camera, its aspect ratio is set when sizing the renderer
+	camera = three.Get("PerspectiveCamera").New(75, 1, 0.1, 1000)
+	placeCamera(cameraPresets[defaultCameraPreset])
 
 	// Create renderer
 	renderer = three.Get("WebGLRenderer").New(map[string]any{
 		"antialias": true,
 	})
-	renderer.Call("setSize", 600, 500)
+	renderer.Call("setPixelRatio", js.Global().Get("devicePixelRatio"))
 
 	// Attach to DOM element
 	document := js.Global().Get("document")
 	container := document.Call("getElementById", "cubeCanvas")
 	container.Call("appendChild", renderer.Get("domElement"))
 
+	// Follow the size of the container
+	resizeRenderer(container)
+	setupResize(container)
+
 	// Set up controls
-	controls := three.Get("OrbitControls").New(camera, renderer.Get("domElement"))
+	controls = three.Get("OrbitControls").New(camera, renderer.Get("domElement"))
 	controls.Set("enableDamping", true)
 	controls.Set("dampingFactor", 0.05)
+	// Remember the initial view for wasmResetView
+	controls.Call("saveState")
 
 	// Set background color
 	scene.Set("background", threeColor.New(0xf0f0f0))
@@ -46,9 +49,21 @@ func initThreeScene(this js.Value, args []js.Value) any {
 	cubeGroup = group.New()
 	scene.Call("add", cubeGroup)
 
+	// Draw the unfolded net next to the 3D cube
+	setupNet()
+
 	// Create initial cube
 	createCube()
 
+	// Turn layers by dragging across the stickers
+	setupDragToTurn(container)
+
+	// Track a piece by double-clicking one of its stickers
+	setupPiecePicking(container)
+
+	// Turn layers with the keyboard
+	setupKeyboard()
+
 	// Set up animation loop
 	var animationCallback js.Func
 	animationCallback = js.FuncOf(func(this js.Value, args []js.Value) any {
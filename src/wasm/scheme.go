@@ -0,0 +1,100 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"encoding/json"
+	"kikokai/src/model"
+	"syscall/js"
+)
+
+// Sticker textures showing the letter of each color, made when the scheme asks for letters
+var letterTextures = map[model.Color]js.Value{}
+
+// stickerHex returns the value of a color in the current scheme as 0xRRGGBB
+func stickerHex(color model.Color) uint32 {
+	return model.CurrentColorScheme().Color(color).RGB()
+}
+
+// setStickerColor colors a face material with a color of the current scheme,
+// drawing the color's letter over it when the scheme asks for letters
+func setStickerColor(material js.Value, color model.Color) {
+	scheme := model.CurrentColorScheme()
+	if !scheme.Letters {
+		material.Get("color").Call("setHex", stickerHex(color))
+		return
+	}
+
+	texture, ok := letterTextures[color]
+	if !ok {
+		texture = letterTexture(scheme.Color(color))
+		letterTextures[color] = texture
+	}
+	// The texture carries the color, the material must not tint it
+	material.Get("color").Call("setHex", 0xFFFFFF)
+	material.Set("map", texture)
+	material.Set("needsUpdate", true)
+}
+
+// letterTexture draws a sticker of the given color with its letter in the middle
+func letterTexture(color model.SchemeColor) js.Value {
+	const size = 128
+	canvas := js.Global().Get("document").Call("createElement", "canvas")
+	canvas.Set("width", size)
+	canvas.Set("height", size)
+
+	ctx := canvas.Call("getContext", "2d")
+	ctx.Set("fillStyle", color.Hex)
+	ctx.Call("fillRect", 0, 0, size, size)
+	ctx.Set("fillStyle", color.LetterHex())
+	ctx.Set("font", "bold 72px sans-serif")
+	ctx.Set("textAlign", "center")
+	ctx.Set("textBaseline", "middle")
+	ctx.Call("fillText", color.Letter, size/2, size/2)
+
+	return three.Get("CanvasTexture").New(canvas)
+}
+
+// disposeTextures frees the letter textures of a previous scheme
+func disposeTextures(textures map[model.Color]js.Value) {
+	for _, texture := range textures {
+		texture.Call("dispose")
+	}
+}
+
+// Use a color scheme given as JSON, as sent by the server's "scheme" event, and redraw the cube.
+// Returns the scheme in use as JSON, or an error message.
+func setColorScheme(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeString {
+		return js.ValueOf("Invalid arguments: expected the color scheme as JSON")
+	}
+
+	var scheme model.ColorScheme
+	if err := json.Unmarshal([]byte(args[0].String()), &scheme); err != nil {
+		return js.ValueOf("Invalid color scheme: " + err.Error())
+	}
+	if err := model.SetColorScheme(scheme); err != nil {
+		return js.ValueOf("Invalid color scheme: " + err.Error())
+	}
+	println("Color scheme:", scheme.Name)
+
+	// Forget the letters of the previous scheme now, since the turns already queued rebuild the cube
+	// in the new scheme as they end, and free them once the cube is redrawn after those turns
+	stale := letterTextures
+	letterTextures = map[model.Color]js.Value{}
+	enqueueUpdate(func() {
+		disposeTextures(stale)
+		createCube()
+	})
+	return getColorScheme(this, nil)
+}
+
+// Get the color scheme in use as JSON
+func getColorScheme(this js.Value, args []js.Value) any {
+	data, err := json.Marshal(model.CurrentColorScheme())
+	if err != nil {
+		return js.ValueOf("Error encoding color scheme: " + err.Error())
+	}
+	return js.ValueOf(string(data))
+}
@@ -0,0 +1,205 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"kikokai/src/model"
+	"syscall/js"
+)
+
+// Appearance of the tracked piece and its path
+const (
+	trackColor   = 0x00bcd4
+	dimmedAlpha  = 0.25
+	outlineScale = 1.12
+)
+
+var (
+	// Piece followed through the turns, identified by its position in the solved cube
+	tracking    bool
+	trackedHome model.CubeCoordinate
+
+	// Number of turns over which the path of the tracked piece is shown
+	trackLength = 10
+
+	// Positions of the tracked piece after each of the last turns, oldest first
+	trackPath []model.CubeCoordinate
+
+	// Line showing trackPath, undefined when there is none
+	trackLine js.Value
+)
+
+// Set up double-clicking a sticker to track its piece
+func setupPiecePicking(container js.Value) {
+	doubleClick := js.FuncOf(onDoubleClick)
+	container.Call("addEventListener", "dblclick", doubleClick)
+	funcs = append(funcs, doubleClick)
+}
+
+// Track the piece under the pointer, or stop tracking it if it already is
+func onDoubleClick(this js.Value, args []js.Value) any {
+	if editMode {
+		return nil
+	}
+	event := args[0]
+	hit, ok := pickSticker(event.Get("clientX").Float(), event.Get("clientY").Float())
+	if !ok {
+		return nil
+	}
+
+	userData := hit.Get("object").Get("userData")
+	pos := model.CubeCoordinate{X: userData.Get("posZ").Int() + 1, Y: userData.Get("posY").Int() + 1, Z: userData.Get("posX").Int() + 1}
+	home, ok := cube.PieceHome(pos)
+	if !ok {
+		return nil
+	}
+	if tracking && home == trackedHome {
+		stopTracking()
+	} else {
+		startTracking(home)
+	}
+	notifyTracking()
+	return nil
+}
+
+func startTracking(home model.CubeCoordinate) {
+	println("Tracking piece", model.PieceName(home))
+	tracking = true
+	trackedHome = home
+	enqueueUpdate(func() {
+		resetTrackPath()
+		createCube()
+	})
+}
+
+func stopTracking() {
+	println("Stopped tracking")
+	tracking = false
+	enqueueUpdate(createCube)
+}
+
+// notifyTracking passes the tracked piece's name, or an empty string, to the page's onWasmTrackingChanged hook, if any
+func notifyTracking() {
+	hook := js.Global().Get("onWasmTrackingChanged")
+	if hook.Type() != js.TypeFunction {
+		return
+	}
+	name := ""
+	if tracking {
+		name = model.PieceName(trackedHome)
+	}
+	hook.Invoke(name)
+}
+
+// recordTurn adds the position of the tracked piece after a turn to its path
+func recordTurn() {
+	if !tracking {
+		return
+	}
+	if pos, ok := cube.FindPiece(trackedHome); ok {
+		trackPath = append(trackPath, pos)
+	}
+	if len(trackPath) > trackLength+1 {
+		trackPath = trackPath[len(trackPath)-trackLength-1:]
+	}
+}
+
+// resetTrackPath starts the path over from the current position of the tracked piece
+func resetTrackPath() {
+	trackPath = nil
+	if !tracking {
+		return
+	}
+	if pos, ok := cube.FindPiece(trackedHome); ok {
+		trackPath = append(trackPath, pos)
+	}
+}
+
+// styleTrackedPiece highlights the tracked piece and dims the others
+func styleTrackedPiece(cubeMesh js.Value, pos model.CubeCoordinate, materials js.Value) {
+	if !tracking {
+		return
+	}
+	if home, ok := cube.PieceHome(pos); ok && home == trackedHome {
+		// An enlarged box showing only its inside faces outlines the piece
+		outline := mesh.New(box.New(cubeSize*outlineScale, cubeSize*outlineScale, cubeSize*outlineScale),
+			three.Get("MeshBasicMaterial").New(map[string]any{
+				"color": trackColor,
+				"side":  three.Get("BackSide"),
+			}))
+		cubeMesh.Call("add", outline)
+		return
+	}
+	for i := 0; i < materials.Length(); i++ {
+		materials.Index(i).Set("transparent", true)
+		materials.Index(i).Set("opacity", dimmedAlpha)
+	}
+}
+
+// drawTrackPath draws the path of the tracked piece through the centers of the positions it went through
+func drawTrackPath() {
+	if !trackLine.IsUndefined() {
+		scene.Call("remove", trackLine)
+		trackLine = js.Undefined()
+	}
+	if !tracking || len(trackPath) < 2 {
+		return
+	}
+
+	points := js.Global().Get("Array").New()
+	for _, pos := range trackPath {
+		// Convert from model array indices (0,1,2) to ThreeJS coordinates
+		points.Call("push", vector3.New(
+			float64(pos.Z-1)*(cubeSize+gap),
+			float64(pos.Y-1)*(cubeSize+gap),
+			float64(pos.X-1)*(cubeSize+gap),
+		))
+	}
+
+	trackLine = group.New()
+	material := three.Get("LineBasicMaterial").New(map[string]any{"color": trackColor})
+	geometry := three.Get("BufferGeometry").New().Call("setFromPoints", points)
+	trackLine.Call("add", three.Get("Line").New(geometry, material))
+
+	// Mark every position, the older ones smaller
+	for i := range trackPath {
+		radius := 0.06 + 0.1*float64(i+1)/float64(len(trackPath))
+		marker := mesh.New(three.Get("SphereGeometry").New(radius, 12, 12),
+			three.Get("MeshBasicMaterial").New(map[string]any{"color": trackColor}))
+		marker.Get("position").Call("copy", points.Index(i))
+		trackLine.Call("add", marker)
+	}
+	scene.Call("add", trackLine)
+}
+
+// Track a piece given by its colors in any order, e.g. wasmTrackPiece("white-blue-red").
+// Without argument, tracking stops.
+func trackPiece(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeString || args[0].String() == "" {
+		stopTracking()
+		notifyTracking()
+		return js.ValueOf("Tracking stopped")
+	}
+
+	home, err := model.ParsePiece(args[0].String())
+	if err != nil {
+		return js.ValueOf("Invalid piece: " + err.Error())
+	}
+	startTracking(home)
+	notifyTracking()
+	return js.ValueOf("Tracking " + model.PieceName(home))
+}
+
+// Set the number of turns over which the path of the tracked piece is shown
+func setTrackLength(this js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeNumber || args[0].Int() < 0 {
+		return js.ValueOf("Invalid arguments: expected a number of turns")
+	}
+	trackLength = args[0].Int()
+	if len(trackPath) > trackLength+1 {
+		trackPath = trackPath[len(trackPath)-trackLength-1:]
+	}
+	enqueueUpdate(createCube)
+	return js.ValueOf(trackLength)
+}